@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -15,6 +16,35 @@ type ErrInvalidRegistration struct {
 	User string
 }
 
+type ErrInvalidArgument struct {
+	Field  string
+	Reason string
+}
+
+type ErrInvalidRegistrationProof struct {
+	User   string
+	Reason string
+}
+
+type ErrInvalidKDFParams struct {
+	User   string
+	Reason string
+}
+
+type ErrInvalidGroupProof struct {
+	Group string
+}
+
+type ErrAccountCompromised struct {
+	User   string
+	Reason string
+}
+
+type ErrInvalidCredentialRotation struct {
+	User   string
+	Reason string
+}
+
 // GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
 // authentication error `401` is thrown due to invalid login credentials
 func (e ErrInvalidChallengeResponse) GRPCStatus() *status.Status {
@@ -76,3 +106,204 @@ func (e ErrInvalidRegistration) GRPCStatus() *status.Status {
 func (e ErrInvalidRegistration) Error() string {
 	return e.GRPCStatus().Err().Error()
 }
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// registration error `400` is thrown due to unusable password derivation params
+func (e ErrInvalidKDFParams) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" invalid KDF parameters for user %s: %s",
+		e.User,
+		e.Reason,
+	)
+
+	st := status.New(
+		400,
+		"registration error:"+msg,
+	)
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrInvalidKDFParams) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// registration error `400` is thrown when the proof that `y1` and `y2` share
+// the same exponent is missing or invalid
+func (e ErrInvalidRegistrationProof) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" invalid proof of possession for user %s: %s",
+		e.User,
+		e.Reason,
+	)
+
+	st := status.New(
+		400,
+		"registration error:"+msg,
+	)
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrInvalidRegistrationProof) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// `InvalidArgument` is thrown when a client-supplied field fails validation.
+// The failing field is reported in a `BadRequest` field violation.
+func (e ErrInvalidArgument) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" invalid %s: %s",
+		e.Field,
+		e.Reason,
+	)
+
+	st := status.New(
+		codes.InvalidArgument,
+		"validation error:"+msg,
+	)
+
+	br := &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: e.Field, Description: e.Reason},
+		},
+	}
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(br, d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrInvalidArgument) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// authentication error `403` is thrown when the account was flagged as compromised,
+// e.g. after the client reused a proof commitment
+func (e ErrAccountCompromised) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" account of user %s is flagged as compromised: %s",
+		e.User,
+		e.Reason,
+	)
+
+	st := status.New(
+		403,
+		"authentication error:"+msg,
+	)
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrAccountCompromised) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// authentication error `401` is thrown when the proof of membership in a group fails
+func (e ErrInvalidGroupProof) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" ZKP verification failed for the membership proof of group %s",
+		e.Group,
+	)
+
+	st := status.New(
+		401,
+		"authentication error: invalid group membership proof provided",
+	)
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrInvalidGroupProof) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
+
+// GRPCStatus : Sets the req'd msg using the `status` and `errdetails` pkg
+// rotation error `400` is thrown when the new public values or their proof of
+// possession are missing or invalid
+func (e ErrInvalidCredentialRotation) GRPCStatus() *status.Status {
+
+	msg := fmt.Sprintf(
+		" invalid new credential for user %s: %s",
+		e.User,
+		e.Reason,
+	)
+
+	st := status.New(
+		400,
+		"rotation error:"+msg,
+	)
+
+	d := &errdetails.LocalizedMessage{
+		Locale:  "en-US",
+		Message: msg,
+	}
+
+	std, err := st.WithDetails(d)
+	if err != nil {
+		return st
+	}
+
+	return std
+}
+
+func (e ErrInvalidCredentialRotation) Error() string {
+	return e.GRPCStatus().Err().Error()
+}
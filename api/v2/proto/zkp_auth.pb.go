@@ -20,20 +20,243 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// parameters of the password-to-secret derivation, chosen at registration
+type KDFParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Algorithm   string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Salt        []byte `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	Time        uint32 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	Memory      uint32 `protobuf:"varint,4,opt,name=memory,proto3" json:"memory,omitempty"`
+	Parallelism uint32 `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+}
+
+func (x *KDFParams) Reset() {
+	*x = KDFParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KDFParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KDFParams) ProtoMessage() {}
+
+func (x *KDFParams) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KDFParams.ProtoReflect.Descriptor instead.
+func (*KDFParams) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KDFParams) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *KDFParams) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *KDFParams) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *KDFParams) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *KDFParams) GetParallelism() uint32 {
+	if x != nil {
+		return x.Parallelism
+	}
+	return 0
+}
+
+// non-interactive Chaum-Pedersen proof that log_g(y1) = log_h(y2)
+type Proof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	R1 string `protobuf:"bytes,1,opt,name=r1,proto3" json:"r1,omitempty"`
+	R2 string `protobuf:"bytes,2,opt,name=r2,proto3" json:"r2,omitempty"`
+	C  string `protobuf:"bytes,3,opt,name=c,proto3" json:"c,omitempty"`
+	S  string `protobuf:"bytes,4,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Proof) GetR1() string {
+	if x != nil {
+		return x.R1
+	}
+	return ""
+}
+
+func (x *Proof) GetR2() string {
+	if x != nil {
+		return x.R2
+	}
+	return ""
+}
+
+func (x *Proof) GetC() string {
+	if x != nil {
+		return x.C
+	}
+	return ""
+}
+
+func (x *Proof) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+// public values of a device-held secret registered as a second factor, with the
+// proof that they share the same exponent
+type DeviceKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Y1    string `protobuf:"bytes,1,opt,name=y1,proto3" json:"y1,omitempty"`
+	Y2    string `protobuf:"bytes,2,opt,name=y2,proto3" json:"y2,omitempty"`
+	Proof *Proof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *DeviceKey) Reset() {
+	*x = DeviceKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceKey) ProtoMessage() {}
+
+func (x *DeviceKey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceKey.ProtoReflect.Descriptor instead.
+func (*DeviceKey) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeviceKey) GetY1() string {
+	if x != nil {
+		return x.Y1
+	}
+	return ""
+}
+
+func (x *DeviceKey) GetY2() string {
+	if x != nil {
+		return x.Y2
+	}
+	return ""
+}
+
+func (x *DeviceKey) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// `protocol` is `chaum-pedersen` (default when empty) or `schnorr`. Schnorr accounts
+// send no `y2`, and their proofs and commitments no `r2`.
 type RegisterRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	Y1   string `protobuf:"bytes,2,opt,name=y1,proto3" json:"y1,omitempty"`
-	Y2   string `protobuf:"bytes,3,opt,name=y2,proto3" json:"y2,omitempty"`
+	User     string     `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Y1       string     `protobuf:"bytes,2,opt,name=y1,proto3" json:"y1,omitempty"`
+	Y2       string     `protobuf:"bytes,3,opt,name=y2,proto3" json:"y2,omitempty"`
+	Kdf      *KDFParams `protobuf:"bytes,4,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	Proof    *Proof     `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	Device   *DeviceKey `protobuf:"bytes,6,opt,name=device,proto3" json:"device,omitempty"`
+	Protocol string     `protobuf:"bytes,7,opt,name=protocol,proto3" json:"protocol,omitempty"`
 }
 
 func (x *RegisterRequest) Reset() {
 	*x = RegisterRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[0]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +269,7 @@ func (x *RegisterRequest) String() string {
 func (*RegisterRequest) ProtoMessage() {}
 
 func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[0]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +282,7 @@ func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
 func (*RegisterRequest) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{0}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RegisterRequest) GetUser() string {
@@ -83,6 +306,34 @@ func (x *RegisterRequest) GetY2() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetKdf() *KDFParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetDevice() *DeviceKey {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
 type RegisterResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -92,7 +343,7 @@ type RegisterResponse struct {
 func (x *RegisterResponse) Reset() {
 	*x = RegisterResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[1]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -105,7 +356,7 @@ func (x *RegisterResponse) String() string {
 func (*RegisterResponse) ProtoMessage() {}
 
 func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[1]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +369,7 @@ func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
 func (*RegisterResponse) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{1}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{4}
 }
 
 // commitment step in the diag.
@@ -127,15 +378,16 @@ type AuthenticationChallengeRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	R1   string `protobuf:"bytes,2,opt,name=r1,proto3" json:"r1,omitempty"`
-	R2   string `protobuf:"bytes,3,opt,name=r2,proto3" json:"r2,omitempty"`
+	User   string      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	R1     string      `protobuf:"bytes,2,opt,name=r1,proto3" json:"r1,omitempty"`
+	R2     string      `protobuf:"bytes,3,opt,name=r2,proto3" json:"r2,omitempty"`
+	Device *Commitment `protobuf:"bytes,4,opt,name=device,proto3" json:"device,omitempty"`
 }
 
 func (x *AuthenticationChallengeRequest) Reset() {
 	*x = AuthenticationChallengeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[2]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -148,7 +400,7 @@ func (x *AuthenticationChallengeRequest) String() string {
 func (*AuthenticationChallengeRequest) ProtoMessage() {}
 
 func (x *AuthenticationChallengeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[2]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +413,7 @@ func (x *AuthenticationChallengeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticationChallengeRequest.ProtoReflect.Descriptor instead.
 func (*AuthenticationChallengeRequest) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{2}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AuthenticationChallengeRequest) GetUser() string {
@@ -185,20 +437,28 @@ func (x *AuthenticationChallengeRequest) GetR2() string {
 	return ""
 }
 
+func (x *AuthenticationChallengeRequest) GetDevice() *Commitment {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
 // challenge step in the diag.
 type AuthenticationChallengeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AuthId string `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
-	C      string `protobuf:"bytes,2,opt,name=c,proto3" json:"c,omitempty"`
+	AuthId string     `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	C      string     `protobuf:"bytes,2,opt,name=c,proto3" json:"c,omitempty"`
+	Kdf    *KDFParams `protobuf:"bytes,3,opt,name=kdf,proto3" json:"kdf,omitempty"`
 }
 
 func (x *AuthenticationChallengeResponse) Reset() {
 	*x = AuthenticationChallengeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[3]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -211,7 +471,7 @@ func (x *AuthenticationChallengeResponse) String() string {
 func (*AuthenticationChallengeResponse) ProtoMessage() {}
 
 func (x *AuthenticationChallengeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[3]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +484,7 @@ func (x *AuthenticationChallengeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticationChallengeResponse.ProtoReflect.Descriptor instead.
 func (*AuthenticationChallengeResponse) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{3}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AuthenticationChallengeResponse) GetAuthId() string {
@@ -241,20 +501,28 @@ func (x *AuthenticationChallengeResponse) GetC() string {
 	return ""
 }
 
+func (x *AuthenticationChallengeResponse) GetKdf() *KDFParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
 // response step in the fiag.
 type AuthenticationAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AuthId string `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
-	S      string `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+	AuthId  string `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	S       string `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+	DeviceS string `protobuf:"bytes,3,opt,name=device_s,json=deviceS,proto3" json:"device_s,omitempty"`
 }
 
 func (x *AuthenticationAnswerRequest) Reset() {
 	*x = AuthenticationAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[4]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -267,7 +535,7 @@ func (x *AuthenticationAnswerRequest) String() string {
 func (*AuthenticationAnswerRequest) ProtoMessage() {}
 
 func (x *AuthenticationAnswerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[4]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +548,7 @@ func (x *AuthenticationAnswerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticationAnswerRequest.ProtoReflect.Descriptor instead.
 func (*AuthenticationAnswerRequest) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{4}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AuthenticationAnswerRequest) GetAuthId() string {
@@ -297,6 +565,13 @@ func (x *AuthenticationAnswerRequest) GetS() string {
 	return ""
 }
 
+func (x *AuthenticationAnswerRequest) GetDeviceS() string {
+	if x != nil {
+		return x.DeviceS
+	}
+	return ""
+}
+
 type AuthenticationAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -308,7 +583,7 @@ type AuthenticationAnswerResponse struct {
 func (x *AuthenticationAnswerResponse) Reset() {
 	*x = AuthenticationAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[5]
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -321,7 +596,7 @@ func (x *AuthenticationAnswerResponse) String() string {
 func (*AuthenticationAnswerResponse) ProtoMessage() {}
 
 func (x *AuthenticationAnswerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[5]
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,7 +609,7 @@ func (x *AuthenticationAnswerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticationAnswerResponse.ProtoReflect.Descriptor instead.
 func (*AuthenticationAnswerResponse) Descriptor() ([]byte, []int) {
-	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{5}
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AuthenticationAnswerResponse) GetSessionId() string {
@@ -344,103 +619,903 @@ func (x *AuthenticationAnswerResponse) GetSessionId() string {
 	return ""
 }
 
-var File_api_v2_proto_zkp_auth_proto protoreflect.FileDescriptor
+// registered public values of a group member, with the KDF params of its secret
+type GroupMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_api_v2_proto_zkp_auth_proto_rawDesc = []byte{
-	0x0a, 0x1b, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x7a,
-	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x7a,
-	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x22, 0x45, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e,
-	0x0a, 0x02, 0x79, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e,
-	0x0a, 0x02, 0x79, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x32, 0x22, 0x12,
-	0x0a, 0x10, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x54, 0x0a, 0x1e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x31, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x32, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x32, 0x22, 0x48, 0x0a, 0x1f, 0x41, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x61,
-	0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75,
-	0x74, 0x68, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x01, 0x63, 0x22, 0x44, 0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x22, 0x3d, 0x0a, 0x1c, 0x41, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x32, 0xac, 0x02, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68,
-	0x12, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x7a,
-	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75,
-	0x74, 0x68, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x76, 0x0a, 0x1d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61,
-	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74,
-	0x68, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x29, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
-	0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a,
-	0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68,
-	0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x7a,
-	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6e, 0x61, 0x74, 0x68, 0x4c, 0x4e, 0x37, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	User string     `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Y1   string     `protobuf:"bytes,2,opt,name=y1,proto3" json:"y1,omitempty"`
+	Y2   string     `protobuf:"bytes,3,opt,name=y2,proto3" json:"y2,omitempty"`
+	Kdf  *KDFParams `protobuf:"bytes,4,opt,name=kdf,proto3" json:"kdf,omitempty"`
 }
 
-var (
-	file_api_v2_proto_zkp_auth_proto_rawDescOnce sync.Once
-	file_api_v2_proto_zkp_auth_proto_rawDescData = file_api_v2_proto_zkp_auth_proto_rawDesc
-)
-
-func file_api_v2_proto_zkp_auth_proto_rawDescGZIP() []byte {
-	file_api_v2_proto_zkp_auth_proto_rawDescOnce.Do(func() {
-		file_api_v2_proto_zkp_auth_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v2_proto_zkp_auth_proto_rawDescData)
-	})
-	return file_api_v2_proto_zkp_auth_proto_rawDescData
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var file_api_v2_proto_zkp_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_api_v2_proto_zkp_auth_proto_goTypes = []interface{}{
-	(*RegisterRequest)(nil),                 // 0: zkp_auth.RegisterRequest
-	(*RegisterResponse)(nil),                // 1: zkp_auth.RegisterResponse
-	(*AuthenticationChallengeRequest)(nil),  // 2: zkp_auth.AuthenticationChallengeRequest
-	(*AuthenticationChallengeResponse)(nil), // 3: zkp_auth.AuthenticationChallengeResponse
-	(*AuthenticationAnswerRequest)(nil),     // 4: zkp_auth.AuthenticationAnswerRequest
-	(*AuthenticationAnswerResponse)(nil),    // 5: zkp_auth.AuthenticationAnswerResponse
-}
-var file_api_v2_proto_zkp_auth_proto_depIdxs = []int32{
-	0, // 0: zkp_auth.Auth.Register:input_type -> zkp_auth.RegisterRequest
-	2, // 1: zkp_auth.Auth.CreateAuthenticationChallenge:input_type -> zkp_auth.AuthenticationChallengeRequest
-	4, // 2: zkp_auth.Auth.VerifyAuthentication:input_type -> zkp_auth.AuthenticationAnswerRequest
-	1, // 3: zkp_auth.Auth.Register:output_type -> zkp_auth.RegisterResponse
-	3, // 4: zkp_auth.Auth.CreateAuthenticationChallenge:output_type -> zkp_auth.AuthenticationChallengeResponse
-	5, // 5: zkp_auth.Auth.VerifyAuthentication:output_type -> zkp_auth.AuthenticationAnswerResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_api_v2_proto_zkp_auth_proto_init() }
-func file_api_v2_proto_zkp_auth_proto_init() {
-	if File_api_v2_proto_zkp_auth_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GroupMember) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GroupMember) GetY1() string {
+	if x != nil {
+		return x.Y1
+	}
+	return ""
+}
+
+func (x *GroupMember) GetY2() string {
+	if x != nil {
+		return x.Y2
+	}
+	return ""
+}
+
+func (x *GroupMember) GetKdf() *KDFParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+type GroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *GroupRequest) Reset() {
+	*x = GroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRequest) ProtoMessage() {}
+
+func (x *GroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRequest.ProtoReflect.Descriptor instead.
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+// registered members of a group, which an anonymous group login proves membership among
+type GroupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Members []*GroupMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *GroupResponse) Reset() {
+	*x = GroupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupResponse) ProtoMessage() {}
+
+func (x *GroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupResponse.ProtoReflect.Descriptor instead.
+func (*GroupResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GroupResponse) GetMembers() []*GroupMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// commitment (r1, r2) of one member in an anonymous group login
+type Commitment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	R1 string `protobuf:"bytes,1,opt,name=r1,proto3" json:"r1,omitempty"`
+	R2 string `protobuf:"bytes,2,opt,name=r2,proto3" json:"r2,omitempty"`
+}
+
+func (x *Commitment) Reset() {
+	*x = Commitment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Commitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Commitment) ProtoMessage() {}
+
+func (x *Commitment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Commitment.ProtoReflect.Descriptor instead.
+func (*Commitment) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Commitment) GetR1() string {
+	if x != nil {
+		return x.R1
+	}
+	return ""
+}
+
+func (x *Commitment) GetR2() string {
+	if x != nil {
+		return x.R2
+	}
+	return ""
+}
+
+// challenge and response (c, s) of one member in an anonymous group login
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	C string `protobuf:"bytes,1,opt,name=c,proto3" json:"c,omitempty"`
+	S string `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Response) GetC() string {
+	if x != nil {
+		return x.C
+	}
+	return ""
+}
+
+func (x *Response) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+// commitment step of an anonymous group login: one commitment per listed member
+type GroupAuthenticationChallengeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group       string        `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Users       []string      `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	Commitments []*Commitment `protobuf:"bytes,3,rep,name=commitments,proto3" json:"commitments,omitempty"`
+}
+
+func (x *GroupAuthenticationChallengeRequest) Reset() {
+	*x = GroupAuthenticationChallengeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupAuthenticationChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuthenticationChallengeRequest) ProtoMessage() {}
+
+func (x *GroupAuthenticationChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuthenticationChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GroupAuthenticationChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GroupAuthenticationChallengeRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupAuthenticationChallengeRequest) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GroupAuthenticationChallengeRequest) GetCommitments() []*Commitment {
+	if x != nil {
+		return x.Commitments
+	}
+	return nil
+}
+
+// challenge step of an anonymous group login
+type GroupAuthenticationChallengeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthId string `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	C      string `protobuf:"bytes,2,opt,name=c,proto3" json:"c,omitempty"`
+}
+
+func (x *GroupAuthenticationChallengeResponse) Reset() {
+	*x = GroupAuthenticationChallengeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupAuthenticationChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuthenticationChallengeResponse) ProtoMessage() {}
+
+func (x *GroupAuthenticationChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuthenticationChallengeResponse.ProtoReflect.Descriptor instead.
+func (*GroupAuthenticationChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GroupAuthenticationChallengeResponse) GetAuthId() string {
+	if x != nil {
+		return x.AuthId
+	}
+	return ""
+}
+
+func (x *GroupAuthenticationChallengeResponse) GetC() string {
+	if x != nil {
+		return x.C
+	}
+	return ""
+}
+
+// response step of an anonymous group login: one response per listed member
+type GroupAuthenticationAnswerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthId    string      `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	Responses []*Response `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (x *GroupAuthenticationAnswerRequest) Reset() {
+	*x = GroupAuthenticationAnswerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupAuthenticationAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuthenticationAnswerRequest) ProtoMessage() {}
+
+func (x *GroupAuthenticationAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuthenticationAnswerRequest.ProtoReflect.Descriptor instead.
+func (*GroupAuthenticationAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GroupAuthenticationAnswerRequest) GetAuthId() string {
+	if x != nil {
+		return x.AuthId
+	}
+	return ""
+}
+
+func (x *GroupAuthenticationAnswerRequest) GetResponses() []*Response {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type GroupAuthenticationAnswerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Group     string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *GroupAuthenticationAnswerResponse) Reset() {
+	*x = GroupAuthenticationAnswerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupAuthenticationAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuthenticationAnswerResponse) ProtoMessage() {}
+
+func (x *GroupAuthenticationAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuthenticationAnswerResponse.ProtoReflect.Descriptor instead.
+func (*GroupAuthenticationAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GroupAuthenticationAnswerResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GroupAuthenticationAnswerResponse) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+// rotation of the password-derived secret: answers a challenge from `CreateAuthenticationChallenge`
+// with the current `x` and submits the new public values with a proof of possession of the new
+// secret, bound to the same `auth_id` and challenge
+type RotateCredentialRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthId  string     `protobuf:"bytes,1,opt,name=auth_id,json=authId,proto3" json:"auth_id,omitempty"`
+	S       string     `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+	DeviceS string     `protobuf:"bytes,3,opt,name=device_s,json=deviceS,proto3" json:"device_s,omitempty"`
+	Y1      string     `protobuf:"bytes,4,opt,name=y1,proto3" json:"y1,omitempty"`
+	Y2      string     `protobuf:"bytes,5,opt,name=y2,proto3" json:"y2,omitempty"`
+	Kdf     *KDFParams `protobuf:"bytes,6,opt,name=kdf,proto3" json:"kdf,omitempty"`
+	Proof   *Proof     `protobuf:"bytes,7,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *RotateCredentialRequest) Reset() {
+	*x = RotateCredentialRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateCredentialRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateCredentialRequest) ProtoMessage() {}
+
+func (x *RotateCredentialRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateCredentialRequest.ProtoReflect.Descriptor instead.
+func (*RotateCredentialRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RotateCredentialRequest) GetAuthId() string {
+	if x != nil {
+		return x.AuthId
+	}
+	return ""
+}
+
+func (x *RotateCredentialRequest) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+func (x *RotateCredentialRequest) GetDeviceS() string {
+	if x != nil {
+		return x.DeviceS
+	}
+	return ""
+}
+
+func (x *RotateCredentialRequest) GetY1() string {
+	if x != nil {
+		return x.Y1
+	}
+	return ""
+}
+
+func (x *RotateCredentialRequest) GetY2() string {
+	if x != nil {
+		return x.Y2
+	}
+	return ""
+}
+
+func (x *RotateCredentialRequest) GetKdf() *KDFParams {
+	if x != nil {
+		return x.Kdf
+	}
+	return nil
+}
+
+func (x *RotateCredentialRequest) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type RotateCredentialResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RotateCredentialResponse) Reset() {
+	*x = RotateCredentialResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateCredentialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateCredentialResponse) ProtoMessage() {}
+
+func (x *RotateCredentialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_proto_zkp_auth_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateCredentialResponse.ProtoReflect.Descriptor instead.
+func (*RotateCredentialResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_proto_zkp_auth_proto_rawDescGZIP(), []int{19}
+}
+
+var File_api_v2_proto_zkp_auth_proto protoreflect.FileDescriptor
+
+var file_api_v2_proto_zkp_auth_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x7a,
+	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x7a,
+	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x4b, 0x44, 0x46, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69,
+	0x73, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x61, 0x6c, 0x6c,
+	0x65, 0x6c, 0x69, 0x73, 0x6d, 0x22, 0x43, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x0e,
+	0x0a, 0x02, 0x72, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x31, 0x12, 0x0e,
+	0x0a, 0x02, 0x72, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x32, 0x12, 0x0c,
+	0x0a, 0x01, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x63, 0x12, 0x0c, 0x0a, 0x01,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x22, 0x52, 0x0a, 0x09, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x31, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x32, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x32, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xdc,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x31, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x32, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x79, 0x32, 0x12, 0x25, 0x0a, 0x03, 0x6b, 0x64, 0x66, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x4b,
+	0x44, 0x46, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x03, 0x6b, 0x64, 0x66, 0x12, 0x25, 0x0a,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a,
+	0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2b, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0x12, 0x0a,
+	0x10, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x82, 0x01, 0x0a, 0x1e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x31, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x32, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x32, 0x12, 0x2c, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x22, 0x6f, 0x0a, 0x1f, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74,
+	0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x63,
+	0x12, 0x25, 0x0a, 0x03, 0x6b, 0x64, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x4b, 0x44, 0x46, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x52, 0x03, 0x6b, 0x64, 0x66, 0x22, 0x5f, 0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x22, 0x3d, 0x0a, 0x1c, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x68, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x31,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x32,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79, 0x32, 0x12, 0x25, 0x0a, 0x03, 0x6b, 0x64,
+	0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x2e, 0x4b, 0x44, 0x46, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x03, 0x6b, 0x64,
+	0x66, 0x22, 0x24, 0x0a, 0x0c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x40, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x7a, 0x6b, 0x70, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x2c, 0x0a, 0x0a, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x31, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x72, 0x32, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x72, 0x32, 0x22, 0x26, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01,
+	0x63, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x22,
+	0x89, 0x01, 0x0a, 0x23, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x4d, 0x0a, 0x24, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01,
+	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x63, 0x22, 0x6d, 0x0a, 0x20, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x6b, 0x70,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x21, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x22, 0xc9, 0x01, 0x0a, 0x17, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x43, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x5f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x53, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79,
+	0x31, 0x12, 0x0e, 0x0a, 0x02, 0x79, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x79,
+	0x32, 0x12, 0x25, 0x0a, 0x03, 0x6b, 0x64, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x4b, 0x44, 0x46, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x52, 0x03, 0x6b, 0x64, 0x66, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22,
+	0x1a, 0x0a, 0x18, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xc8, 0x05, 0x0a, 0x04,
+	0x41, 0x75, 0x74, 0x68, 0x12, 0x43, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x19, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x7a, 0x6b,
+	0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x76, 0x0a, 0x1d, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x2e, 0x7a, 0x6b, 0x70,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x67, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x7a, 0x6b, 0x70, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x26, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x16, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74,
+	0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x85, 0x01, 0x0a, 0x22, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x12, 0x2d, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2e, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x76, 0x0a, 0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a,
+	0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x7a, 0x6b, 0x70,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x10, 0x52, 0x6f, 0x74,
+	0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x21, 0x2e,
+	0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x22, 0x2e, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x72, 0x69, 0x6e, 0x61, 0x74, 0x68, 0x4c, 0x4e, 0x37, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x7a, 0x6b, 0x70, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_v2_proto_zkp_auth_proto_rawDescOnce sync.Once
+	file_api_v2_proto_zkp_auth_proto_rawDescData = file_api_v2_proto_zkp_auth_proto_rawDesc
+)
+
+func file_api_v2_proto_zkp_auth_proto_rawDescGZIP() []byte {
+	file_api_v2_proto_zkp_auth_proto_rawDescOnce.Do(func() {
+		file_api_v2_proto_zkp_auth_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_v2_proto_zkp_auth_proto_rawDescData)
+	})
+	return file_api_v2_proto_zkp_auth_proto_rawDescData
+}
+
+var file_api_v2_proto_zkp_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_v2_proto_zkp_auth_proto_goTypes = []interface{}{
+	(*KDFParams)(nil),                            // 0: zkp_auth.KDFParams
+	(*Proof)(nil),                                // 1: zkp_auth.Proof
+	(*DeviceKey)(nil),                            // 2: zkp_auth.DeviceKey
+	(*RegisterRequest)(nil),                      // 3: zkp_auth.RegisterRequest
+	(*RegisterResponse)(nil),                     // 4: zkp_auth.RegisterResponse
+	(*AuthenticationChallengeRequest)(nil),       // 5: zkp_auth.AuthenticationChallengeRequest
+	(*AuthenticationChallengeResponse)(nil),      // 6: zkp_auth.AuthenticationChallengeResponse
+	(*AuthenticationAnswerRequest)(nil),          // 7: zkp_auth.AuthenticationAnswerRequest
+	(*AuthenticationAnswerResponse)(nil),         // 8: zkp_auth.AuthenticationAnswerResponse
+	(*GroupMember)(nil),                          // 9: zkp_auth.GroupMember
+	(*GroupRequest)(nil),                         // 10: zkp_auth.GroupRequest
+	(*GroupResponse)(nil),                        // 11: zkp_auth.GroupResponse
+	(*Commitment)(nil),                           // 12: zkp_auth.Commitment
+	(*Response)(nil),                             // 13: zkp_auth.Response
+	(*GroupAuthenticationChallengeRequest)(nil),  // 14: zkp_auth.GroupAuthenticationChallengeRequest
+	(*GroupAuthenticationChallengeResponse)(nil), // 15: zkp_auth.GroupAuthenticationChallengeResponse
+	(*GroupAuthenticationAnswerRequest)(nil),     // 16: zkp_auth.GroupAuthenticationAnswerRequest
+	(*GroupAuthenticationAnswerResponse)(nil),    // 17: zkp_auth.GroupAuthenticationAnswerResponse
+	(*RotateCredentialRequest)(nil),              // 18: zkp_auth.RotateCredentialRequest
+	(*RotateCredentialResponse)(nil),             // 19: zkp_auth.RotateCredentialResponse
+}
+var file_api_v2_proto_zkp_auth_proto_depIdxs = []int32{
+	1,  // 0: zkp_auth.DeviceKey.proof:type_name -> zkp_auth.Proof
+	0,  // 1: zkp_auth.RegisterRequest.kdf:type_name -> zkp_auth.KDFParams
+	1,  // 2: zkp_auth.RegisterRequest.proof:type_name -> zkp_auth.Proof
+	2,  // 3: zkp_auth.RegisterRequest.device:type_name -> zkp_auth.DeviceKey
+	12, // 4: zkp_auth.AuthenticationChallengeRequest.device:type_name -> zkp_auth.Commitment
+	0,  // 5: zkp_auth.AuthenticationChallengeResponse.kdf:type_name -> zkp_auth.KDFParams
+	0,  // 6: zkp_auth.GroupMember.kdf:type_name -> zkp_auth.KDFParams
+	9,  // 7: zkp_auth.GroupResponse.members:type_name -> zkp_auth.GroupMember
+	12, // 8: zkp_auth.GroupAuthenticationChallengeRequest.commitments:type_name -> zkp_auth.Commitment
+	13, // 9: zkp_auth.GroupAuthenticationAnswerRequest.responses:type_name -> zkp_auth.Response
+	0,  // 10: zkp_auth.RotateCredentialRequest.kdf:type_name -> zkp_auth.KDFParams
+	1,  // 11: zkp_auth.RotateCredentialRequest.proof:type_name -> zkp_auth.Proof
+	3,  // 12: zkp_auth.Auth.Register:input_type -> zkp_auth.RegisterRequest
+	5,  // 13: zkp_auth.Auth.CreateAuthenticationChallenge:input_type -> zkp_auth.AuthenticationChallengeRequest
+	7,  // 14: zkp_auth.Auth.VerifyAuthentication:input_type -> zkp_auth.AuthenticationAnswerRequest
+	10, // 15: zkp_auth.Auth.GetGroup:input_type -> zkp_auth.GroupRequest
+	14, // 16: zkp_auth.Auth.CreateGroupAuthenticationChallenge:input_type -> zkp_auth.GroupAuthenticationChallengeRequest
+	16, // 17: zkp_auth.Auth.VerifyGroupAuthentication:input_type -> zkp_auth.GroupAuthenticationAnswerRequest
+	18, // 18: zkp_auth.Auth.RotateCredential:input_type -> zkp_auth.RotateCredentialRequest
+	4,  // 19: zkp_auth.Auth.Register:output_type -> zkp_auth.RegisterResponse
+	6,  // 20: zkp_auth.Auth.CreateAuthenticationChallenge:output_type -> zkp_auth.AuthenticationChallengeResponse
+	8,  // 21: zkp_auth.Auth.VerifyAuthentication:output_type -> zkp_auth.AuthenticationAnswerResponse
+	11, // 22: zkp_auth.Auth.GetGroup:output_type -> zkp_auth.GroupResponse
+	15, // 23: zkp_auth.Auth.CreateGroupAuthenticationChallenge:output_type -> zkp_auth.GroupAuthenticationChallengeResponse
+	17, // 24: zkp_auth.Auth.VerifyGroupAuthentication:output_type -> zkp_auth.GroupAuthenticationAnswerResponse
+	19, // 25: zkp_auth.Auth.RotateCredential:output_type -> zkp_auth.RotateCredentialResponse
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_api_v2_proto_zkp_auth_proto_init() }
+func file_api_v2_proto_zkp_auth_proto_init() {
+	if File_api_v2_proto_zkp_auth_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
 		file_api_v2_proto_zkp_auth_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterRequest); i {
+			switch v := v.(*KDFParams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -452,7 +1527,7 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			}
 		}
 		file_api_v2_proto_zkp_auth_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterResponse); i {
+			switch v := v.(*Proof); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -464,7 +1539,7 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			}
 		}
 		file_api_v2_proto_zkp_auth_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticationChallengeRequest); i {
+			switch v := v.(*DeviceKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -476,7 +1551,7 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			}
 		}
 		file_api_v2_proto_zkp_auth_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticationChallengeResponse); i {
+			switch v := v.(*RegisterRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -488,7 +1563,7 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			}
 		}
 		file_api_v2_proto_zkp_auth_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticationAnswerRequest); i {
+			switch v := v.(*RegisterResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -500,6 +1575,42 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			}
 		}
 		file_api_v2_proto_zkp_auth_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticationChallengeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticationChallengeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticationAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AuthenticationAnswerResponse); i {
 			case 0:
 				return &v.state
@@ -511,6 +1622,138 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 				return nil
 			}
 		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Commitment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupAuthenticationChallengeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupAuthenticationChallengeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupAuthenticationAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupAuthenticationAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateCredentialRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v2_proto_zkp_auth_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateCredentialResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -518,7 +1761,7 @@ func file_api_v2_proto_zkp_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v2_proto_zkp_auth_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,10 @@ type AuthClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	CreateAuthenticationChallenge(ctx context.Context, in *AuthenticationChallengeRequest, opts ...grpc.CallOption) (*AuthenticationChallengeResponse, error)
 	VerifyAuthentication(ctx context.Context, in *AuthenticationAnswerRequest, opts ...grpc.CallOption) (*AuthenticationAnswerResponse, error)
+	GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	CreateGroupAuthenticationChallenge(ctx context.Context, in *GroupAuthenticationChallengeRequest, opts ...grpc.CallOption) (*GroupAuthenticationChallengeResponse, error)
+	VerifyGroupAuthentication(ctx context.Context, in *GroupAuthenticationAnswerRequest, opts ...grpc.CallOption) (*GroupAuthenticationAnswerResponse, error)
+	RotateCredential(ctx context.Context, in *RotateCredentialRequest, opts ...grpc.CallOption) (*RotateCredentialResponse, error)
 }
 
 type authClient struct {
@@ -57,6 +61,42 @@ func (c *authClient) VerifyAuthentication(ctx context.Context, in *Authenticatio
 	return out, nil
 }
 
+func (c *authClient) GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.Auth/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) CreateGroupAuthenticationChallenge(ctx context.Context, in *GroupAuthenticationChallengeRequest, opts ...grpc.CallOption) (*GroupAuthenticationChallengeResponse, error) {
+	out := new(GroupAuthenticationChallengeResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.Auth/CreateGroupAuthenticationChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) VerifyGroupAuthentication(ctx context.Context, in *GroupAuthenticationAnswerRequest, opts ...grpc.CallOption) (*GroupAuthenticationAnswerResponse, error) {
+	out := new(GroupAuthenticationAnswerResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.Auth/VerifyGroupAuthentication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) RotateCredential(ctx context.Context, in *RotateCredentialRequest, opts ...grpc.CallOption) (*RotateCredentialResponse, error) {
+	out := new(RotateCredentialResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.Auth/RotateCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
@@ -64,6 +104,10 @@ type AuthServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	CreateAuthenticationChallenge(context.Context, *AuthenticationChallengeRequest) (*AuthenticationChallengeResponse, error)
 	VerifyAuthentication(context.Context, *AuthenticationAnswerRequest) (*AuthenticationAnswerResponse, error)
+	GetGroup(context.Context, *GroupRequest) (*GroupResponse, error)
+	CreateGroupAuthenticationChallenge(context.Context, *GroupAuthenticationChallengeRequest) (*GroupAuthenticationChallengeResponse, error)
+	VerifyGroupAuthentication(context.Context, *GroupAuthenticationAnswerRequest) (*GroupAuthenticationAnswerResponse, error)
+	RotateCredential(context.Context, *RotateCredentialRequest) (*RotateCredentialResponse, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -80,6 +124,18 @@ func (UnimplementedAuthServer) CreateAuthenticationChallenge(context.Context, *A
 func (UnimplementedAuthServer) VerifyAuthentication(context.Context, *AuthenticationAnswerRequest) (*AuthenticationAnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuthentication not implemented")
 }
+func (UnimplementedAuthServer) GetGroup(context.Context, *GroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedAuthServer) CreateGroupAuthenticationChallenge(context.Context, *GroupAuthenticationChallengeRequest) (*GroupAuthenticationChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupAuthenticationChallenge not implemented")
+}
+func (UnimplementedAuthServer) VerifyGroupAuthentication(context.Context, *GroupAuthenticationAnswerRequest) (*GroupAuthenticationAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyGroupAuthentication not implemented")
+}
+func (UnimplementedAuthServer) RotateCredential(context.Context, *RotateCredentialRequest) (*RotateCredentialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateCredential not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -147,6 +203,78 @@ func _Auth_VerifyAuthentication_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.Auth/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_CreateGroupAuthenticationChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupAuthenticationChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).CreateGroupAuthenticationChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.Auth/CreateGroupAuthenticationChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).CreateGroupAuthenticationChallenge(ctx, req.(*GroupAuthenticationChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_VerifyGroupAuthentication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupAuthenticationAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).VerifyGroupAuthentication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.Auth/VerifyGroupAuthentication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).VerifyGroupAuthentication(ctx, req.(*GroupAuthenticationAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_RotateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateCredentialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).RotateCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.Auth/RotateCredential",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).RotateCredential(ctx, req.(*RotateCredentialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Auth_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "zkp_auth.Auth",
 	HandlerType: (*AuthServer)(nil),
@@ -163,6 +291,22 @@ var _Auth_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifyAuthentication",
 			Handler:    _Auth_VerifyAuthentication_Handler,
 		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _Auth_GetGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroupAuthenticationChallenge",
+			Handler:    _Auth_CreateGroupAuthenticationChallenge_Handler,
+		},
+		{
+			MethodName: "VerifyGroupAuthentication",
+			Handler:    _Auth_VerifyGroupAuthentication_Handler,
+		},
+		{
+			MethodName: "RotateCredential",
+			Handler:    _Auth_RotateCredential_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v2/proto/zkp_auth.proto",
@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package zkp_auth
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// AuthClient is the client API for Auth service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthClient interface {
+	// GetVersions and GetParameters are the calls without a header: clients use them to pick
+	// a version and to fetch the parameters the values of the other calls are encoded in
+	GetVersions(ctx context.Context, in *VersionsRequest, opts ...grpc.CallOption) (*VersionsResponse, error)
+	GetParameters(ctx context.Context, in *ParametersRequest, opts ...grpc.CallOption) (*ParametersResponse, error)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	GetKDFParams(ctx context.Context, in *KDFParamsRequest, opts ...grpc.CallOption) (*KDFParamsResponse, error)
+	CreateAuthenticationChallenge(ctx context.Context, in *AuthenticationChallengeRequest, opts ...grpc.CallOption) (*AuthenticationChallengeResponse, error)
+	VerifyAuthentication(ctx context.Context, in *AuthenticationAnswerRequest, opts ...grpc.CallOption) (*AuthenticationAnswerResponse, error)
+	GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	CreateGroupAuthenticationChallenge(ctx context.Context, in *GroupAuthenticationChallengeRequest, opts ...grpc.CallOption) (*GroupAuthenticationChallengeResponse, error)
+	VerifyGroupAuthentication(ctx context.Context, in *GroupAuthenticationAnswerRequest, opts ...grpc.CallOption) (*GroupAuthenticationAnswerResponse, error)
+	RotateCredential(ctx context.Context, in *RotateCredentialRequest, opts ...grpc.CallOption) (*RotateCredentialResponse, error)
+}
+
+type authClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthClient(cc grpc.ClientConnInterface) AuthClient {
+	return &authClient{cc}
+}
+
+func (c *authClient) GetVersions(ctx context.Context, in *VersionsRequest, opts ...grpc.CallOption) (*VersionsResponse, error) {
+	out := new(VersionsResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/GetVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetParameters(ctx context.Context, in *ParametersRequest, opts ...grpc.CallOption) (*ParametersResponse, error) {
+	out := new(ParametersResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/GetParameters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetKDFParams(ctx context.Context, in *KDFParamsRequest, opts ...grpc.CallOption) (*KDFParamsResponse, error) {
+	out := new(KDFParamsResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/GetKDFParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) CreateAuthenticationChallenge(ctx context.Context, in *AuthenticationChallengeRequest, opts ...grpc.CallOption) (*AuthenticationChallengeResponse, error) {
+	out := new(AuthenticationChallengeResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/CreateAuthenticationChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) VerifyAuthentication(ctx context.Context, in *AuthenticationAnswerRequest, opts ...grpc.CallOption) (*AuthenticationAnswerResponse, error) {
+	out := new(AuthenticationAnswerResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/VerifyAuthentication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/GetGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) CreateGroupAuthenticationChallenge(ctx context.Context, in *GroupAuthenticationChallengeRequest, opts ...grpc.CallOption) (*GroupAuthenticationChallengeResponse, error) {
+	out := new(GroupAuthenticationChallengeResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/CreateGroupAuthenticationChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) VerifyGroupAuthentication(ctx context.Context, in *GroupAuthenticationAnswerRequest, opts ...grpc.CallOption) (*GroupAuthenticationAnswerResponse, error) {
+	out := new(GroupAuthenticationAnswerResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/VerifyGroupAuthentication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) RotateCredential(ctx context.Context, in *RotateCredentialRequest, opts ...grpc.CallOption) (*RotateCredentialResponse, error) {
+	out := new(RotateCredentialResponse)
+	err := c.cc.Invoke(ctx, "/zkp_auth.v3.Auth/RotateCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServer is the server API for Auth service.
+// All implementations must embed UnimplementedAuthServer
+// for forward compatibility
+type AuthServer interface {
+	// GetVersions and GetParameters are the calls without a header: clients use them to pick
+	// a version and to fetch the parameters the values of the other calls are encoded in
+	GetVersions(context.Context, *VersionsRequest) (*VersionsResponse, error)
+	GetParameters(context.Context, *ParametersRequest) (*ParametersResponse, error)
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	GetKDFParams(context.Context, *KDFParamsRequest) (*KDFParamsResponse, error)
+	CreateAuthenticationChallenge(context.Context, *AuthenticationChallengeRequest) (*AuthenticationChallengeResponse, error)
+	VerifyAuthentication(context.Context, *AuthenticationAnswerRequest) (*AuthenticationAnswerResponse, error)
+	GetGroup(context.Context, *GroupRequest) (*GroupResponse, error)
+	CreateGroupAuthenticationChallenge(context.Context, *GroupAuthenticationChallengeRequest) (*GroupAuthenticationChallengeResponse, error)
+	VerifyGroupAuthentication(context.Context, *GroupAuthenticationAnswerRequest) (*GroupAuthenticationAnswerResponse, error)
+	RotateCredential(context.Context, *RotateCredentialRequest) (*RotateCredentialResponse, error)
+	mustEmbedUnimplementedAuthServer()
+}
+
+// UnimplementedAuthServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthServer struct {
+}
+
+func (UnimplementedAuthServer) GetVersions(context.Context, *VersionsRequest) (*VersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersions not implemented")
+}
+func (UnimplementedAuthServer) GetParameters(context.Context, *ParametersRequest) (*ParametersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParameters not implemented")
+}
+func (UnimplementedAuthServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthServer) GetKDFParams(context.Context, *KDFParamsRequest) (*KDFParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKDFParams not implemented")
+}
+func (UnimplementedAuthServer) CreateAuthenticationChallenge(context.Context, *AuthenticationChallengeRequest) (*AuthenticationChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuthenticationChallenge not implemented")
+}
+func (UnimplementedAuthServer) VerifyAuthentication(context.Context, *AuthenticationAnswerRequest) (*AuthenticationAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuthentication not implemented")
+}
+func (UnimplementedAuthServer) GetGroup(context.Context, *GroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedAuthServer) CreateGroupAuthenticationChallenge(context.Context, *GroupAuthenticationChallengeRequest) (*GroupAuthenticationChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroupAuthenticationChallenge not implemented")
+}
+func (UnimplementedAuthServer) VerifyGroupAuthentication(context.Context, *GroupAuthenticationAnswerRequest) (*GroupAuthenticationAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyGroupAuthentication not implemented")
+}
+func (UnimplementedAuthServer) RotateCredential(context.Context, *RotateCredentialRequest) (*RotateCredentialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateCredential not implemented")
+}
+func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
+
+// UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServer will
+// result in compilation errors.
+type UnsafeAuthServer interface {
+	mustEmbedUnimplementedAuthServer()
+}
+
+func RegisterAuthServer(s *grpc.Server, srv AuthServer) {
+	s.RegisterService(&_Auth_serviceDesc, srv)
+}
+
+func _Auth_GetVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/GetVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetVersions(ctx, req.(*VersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/GetParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetParameters(ctx, req.(*ParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetKDFParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KDFParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetKDFParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/GetKDFParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetKDFParams(ctx, req.(*KDFParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_CreateAuthenticationChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticationChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).CreateAuthenticationChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/CreateAuthenticationChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).CreateAuthenticationChallenge(ctx, req.(*AuthenticationChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_VerifyAuthentication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticationAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).VerifyAuthentication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/VerifyAuthentication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).VerifyAuthentication(ctx, req.(*AuthenticationAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_CreateGroupAuthenticationChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupAuthenticationChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).CreateGroupAuthenticationChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/CreateGroupAuthenticationChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).CreateGroupAuthenticationChallenge(ctx, req.(*GroupAuthenticationChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_VerifyGroupAuthentication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupAuthenticationAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).VerifyGroupAuthentication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/VerifyGroupAuthentication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).VerifyGroupAuthentication(ctx, req.(*GroupAuthenticationAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_RotateCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateCredentialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).RotateCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/zkp_auth.v3.Auth/RotateCredential",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).RotateCredential(ctx, req.(*RotateCredentialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Auth_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "zkp_auth.v3.Auth",
+	HandlerType: (*AuthServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVersions",
+			Handler:    _Auth_GetVersions_Handler,
+		},
+		{
+			MethodName: "GetParameters",
+			Handler:    _Auth_GetParameters_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _Auth_Register_Handler,
+		},
+		{
+			MethodName: "GetKDFParams",
+			Handler:    _Auth_GetKDFParams_Handler,
+		},
+		{
+			MethodName: "CreateAuthenticationChallenge",
+			Handler:    _Auth_CreateAuthenticationChallenge_Handler,
+		},
+		{
+			MethodName: "VerifyAuthentication",
+			Handler:    _Auth_VerifyAuthentication_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _Auth_GetGroup_Handler,
+		},
+		{
+			MethodName: "CreateGroupAuthenticationChallenge",
+			Handler:    _Auth_CreateGroupAuthenticationChallenge_Handler,
+		},
+		{
+			MethodName: "VerifyGroupAuthentication",
+			Handler:    _Auth_VerifyGroupAuthentication_Handler,
+		},
+		{
+			MethodName: "RotateCredential",
+			Handler:    _Auth_RotateCredential_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v3/proto/zkp_auth.proto",
+}
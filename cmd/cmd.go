@@ -3,25 +3,125 @@ package cmd
 import (
 	"encoding/json"
 	"log"
+	"math/big"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	api "github.com/srinathLN7/zkp_auth/api/v3/proto"
 	"github.com/srinathLN7/zkp_auth/internal/client"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
 )
 
 var (
-	user     string
-	password string
+	user      string
+	password  string
+	group     string
+	paramFile string
+	preset    string
+	protocol  string
+
+	// parameter cache of the commands that talk to the server
+	paramsCache string
+
+	// `register`, `login` and `rotate` flags
+	deviceKey string
+
+	// `register` and `rotate` flags
+	kdfAlgorithm   string
+	kdfTime        uint32
+	kdfMemory      uint32
+	kdfParallelism uint32
+
+	// `rotate` flags
+	newPassword string
+
+	// `group-login` flags
+	memberGroup string
+
+	// `genparams` flags
+	bits    int
+	seed    string
+	outFile string
 )
 
 func SetupFlags() {
 	RootCmd.PersistentFlags().StringVarP(&user, "user", "u", "", "User")
 	RootCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "Password")
+	RootCmd.PersistentFlags().StringVarP(&group, "group", "g", cp_zkp.GroupModP, "Group the protocol runs over (modp, p256, ristretto255)")
+	RootCmd.PersistentFlags().StringVar(&paramFile, "params", "", "Parameter file generated with `genparams` (modp group only)")
+	RootCmd.PersistentFlags().StringVar(&preset, "preset", "", "Built-in RFC 3526 / RFC 7919 parameter preset, e.g. ffdhe2048 (modp group only)")
+	RootCmd.PersistentFlags().StringVar(&protocol, "protocol", cp_zkp.ProtocolChaumPedersen, "Identification protocol the account is registered with (chaum-pedersen, schnorr)")
+	RootCmd.PersistentFlags().StringVar(&paramsCache, "params-cache", ".zkp_auth_params.json", "File pinning the parameters fetched from the server (empty disables pinning)")
+
+	registerCmd.Flags().StringVar(&deviceKey, "device-key", "", "Generate a device key into this new file and require it at every login (two-factor)")
+	loginCmd.Flags().StringVar(&deviceKey, "device-key", "", "Device key file of a two-factor account")
+	rotateCmd.Flags().StringVar(&deviceKey, "device-key", "", "Device key file of a two-factor account")
+
+	registerCmd.Flags().StringVar(&kdfAlgorithm, "kdf", cp_zkp.KDFArgon2id, "Password derivation function (argon2id, scrypt)")
+	registerCmd.Flags().Uint32Var(&kdfTime, "kdf-time", 3, "Argon2id passes (always 1 for scrypt)")
+	registerCmd.Flags().Uint32Var(&kdfMemory, "kdf-memory", 64*1024, "KDF memory in KiB (the cost N for scrypt, a power of two)")
+	registerCmd.Flags().Uint32Var(&kdfParallelism, "kdf-parallelism", 4, "KDF lanes (Argon2id threads, scrypt p)")
+
+	rotateCmd.Flags().StringVar(&newPassword, "new-password", "", "New password")
+	rotateCmd.Flags().StringVar(&kdfAlgorithm, "kdf", cp_zkp.KDFArgon2id, "Password derivation function of the new password (argon2id, scrypt)")
+	rotateCmd.Flags().Uint32Var(&kdfTime, "kdf-time", 3, "Argon2id passes (always 1 for scrypt)")
+	rotateCmd.Flags().Uint32Var(&kdfMemory, "kdf-memory", 64*1024, "KDF memory in KiB (the cost N for scrypt, a power of two)")
+	rotateCmd.Flags().Uint32Var(&kdfParallelism, "kdf-parallelism", 4, "KDF lanes (Argon2id threads, scrypt p)")
+
+	groupLoginCmd.Flags().StringVar(&memberGroup, "name", "", "Name of the group to log in to anonymously")
+
+	genParamsCmd.Flags().IntVar(&bits, "bits", 2048, "Bit size of the safe prime p")
+	genParamsCmd.Flags().StringVar(&seed, "seed", "", "Public seed used to derive g and h (random if empty)")
+	genParamsCmd.Flags().StringVarP(&outFile, "out", "o", "params.json", "Output parameter file")
+
 	RootCmd.AddCommand(registerCmd)
 	RootCmd.AddCommand(loginCmd)
+	RootCmd.AddCommand(rotateCmd)
+	RootCmd.AddCommand(groupLoginCmd)
+	RootCmd.AddCommand(genParamsCmd)
+}
+
+// newCPZKP creates the protocol instance selected by the `group`, `params`, `preset` and `protocol` flags.
+// Unless `params` or `preset` selects local parameters, the server's are fetched and pinned in `params-cache`.
+func newCPZKP(grpcClient api.AuthClient) *cp_zkp.CPZKP {
+	cpzkp, err := cp_zkp.NewCPZKPWithGroup(group)
+	if err != nil {
+		log.Fatalf("error setting up the zkp protocol %s", err.Error())
+	}
+	cpzkp.ParamFile = paramFile
+	cpzkp.Preset = preset
+	cpzkp.Protocol = protocol
+
+	if paramFile == "" && preset == "" {
+		if _, err := client.FetchParams(grpcClient, cpzkp, paramsCache); err != nil {
+			log.Fatalf("error fetching the zkp parameters %s", err.Error())
+		}
+	}
+	return cpzkp
+}
+
+// newKDFParams creates the password derivation params selected by the `register` and `rotate` flags
+func newKDFParams() *cp_zkp.KDFParams {
+	kdf, err := cp_zkp.NewKDFParams()
+	if err != nil {
+		log.Fatalf("error generating salt %s", err.Error())
+	}
+
+	kdf.Algorithm = kdfAlgorithm
+	kdf.Time = kdfTime
+	kdf.Memory = kdfMemory
+	kdf.Parallelism = kdfParallelism
+	if kdfAlgorithm == cp_zkp.KDFScrypt {
+		kdf.Time = 1
+	}
+
+	if err := kdf.Validate(); err != nil {
+		log.Fatalf("error setting up the password derivation %s", err.Error())
+	}
+	return kdf
 }
 
 var RootCmd = &cobra.Command{
@@ -53,7 +153,21 @@ var registerCmd = &cobra.Command{
 		if err != nil {
 			log.Fatalf("error setting up grpc client %s", err.Error())
 		}
-		regRes, err := client.Register(*grpcClient, user, password)
+		cpzkp := newCPZKP(*grpcClient)
+
+		// The device key is written before registering, so a registered key is never lost
+		var device *big.Int
+		if deviceKey != "" {
+			device, err = client.NewDeviceKey(cpzkp)
+			if err != nil {
+				log.Fatalf("error generating device key %s", err.Error())
+			}
+			if err := client.WriteDeviceKey(deviceKey, device); err != nil {
+				log.Fatalf("error writing device key %s", err.Error())
+			}
+		}
+
+		regRes, err := client.RegisterWithDevice(*grpcClient, cpzkp, user, password, newKDFParams(), device)
 		if err != nil {
 			return
 		}
@@ -75,7 +189,67 @@ var loginCmd = &cobra.Command{
 		if err != nil {
 			log.Fatalf("error setting up grpc client %s", err.Error())
 		}
-		loginRes, err := client.LogIn(*grpcClient, user, password)
+		var device *big.Int
+		if deviceKey != "" {
+			device, err = client.ReadDeviceKey(deviceKey)
+			if err != nil {
+				log.Fatalf("error reading device key %s", err.Error())
+			}
+		}
+
+		loginRes, err := client.LogInWithDevice(*grpcClient, newCPZKP(*grpcClient), user, password, device)
+		if err != nil {
+			return
+		}
+
+		resJSON, err := json.Marshal(loginRes)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace the password of a registered user",
+	Run: func(cmd *cobra.Command, args []string) {
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+		var device *big.Int
+		if deviceKey != "" {
+			device, err = client.ReadDeviceKey(deviceKey)
+			if err != nil {
+				log.Fatalf("error reading device key %s", err.Error())
+			}
+		}
+
+		rotateRes, err := client.RotateCredential(*grpcClient, newCPZKP(*grpcClient), user, password, newPassword, newKDFParams(), device)
+		if err != nil {
+			return
+		}
+
+		resJSON, err := json.Marshal(rotateRes)
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green(string(resJSON))
+	},
+}
+
+var groupLoginCmd = &cobra.Command{
+	Use:   "group-login",
+	Short: "Log in anonymously to a group the user is a member of",
+	Run: func(cmd *cobra.Command, args []string) {
+		grpcClient, err := client.SetupGRPCClient()
+		if err != nil {
+			log.Fatalf("error setting up grpc client %s", err.Error())
+		}
+		loginRes, err := client.GroupLogIn(*grpcClient, newCPZKP(*grpcClient), memberGroup, user, password)
 		if err != nil {
 			return
 		}
@@ -88,3 +262,22 @@ var loginCmd = &cobra.Command{
 		color.Green(string(resJSON))
 	},
 }
+
+var genParamsCmd = &cobra.Command{
+	Use:   "genparams",
+	Short: "Generate a safe prime parameter file with generators derived from a public seed",
+	Run: func(cmd *cobra.Command, args []string) {
+		color.Yellow("generating a %d-bit safe prime, this may take a while...", bits)
+
+		paramFile, err := cp_zkp.GenerateParamFile(bits, []byte(seed))
+		if err != nil {
+			log.Fatal("error:", err)
+		}
+
+		if err := cp_zkp.WriteParamFile(outFile, paramFile); err != nil {
+			log.Fatal("error:", err)
+		}
+
+		color.Green("parameters written to %s (seed: %s)", outFile, paramFile.Seed)
+	},
+}
@@ -2,14 +2,17 @@ package client
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/joho/godotenv"
-	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
-	"github.com/srinathLN7/zkp_auth/lib/util"
+	api "github.com/srinathLN7/zkp_auth/api/v3/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -49,41 +52,95 @@ func SetupGRPCClient() (*api.AuthClient, error) {
 	return &grpcClient, nil
 }
 
-// RegisterUser Registers the user with the given password and returns a message, if successful
-func Register(grpcClient api.AuthClient, user, password string) (*RegRes, error) {
+// RegisterUser Registers the user with the given password and returns a message, if successful.
+// `cpzkp` selects the group and parameters, which must match the server's.
+// `kdf` selects how the password is stretched into `x`; nil uses Argon2id with a fresh salt
+// and the default cost. The KDF params are stored by the server and returned at login.
+func Register(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, user, password string, kdf *cp_zkp.KDFParams) (*RegRes, error) {
+	return RegisterWithDevice(grpcClient, cpzkp, user, password, kdf, nil)
+}
+
+// RegisterWithDevice registers a two-factor account: next to the password-derived secret,
+// the public values of the device-held secret `device` are registered, and every login must
+// prove both secrets. A nil `device` registers a password-only account.
+func RegisterWithDevice(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, user, password string, kdf *cp_zkp.KDFParams, device *big.Int) (*RegRes, error) {
 
 	// Generate the system parameters
-	cpzkp, err := cp_zkp.NewCPZKP()
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
 	if err != nil {
-		log.Fatal(err)
+		err = fmt.Errorf("failed to initialize the ZKP params: %w", err)
+		log.Print(err)
 		return nil, err
 	}
 
-	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	protocol, err := clientProtocol(cpzkp, device)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	if kdf == nil {
+		kdf, err = cp_zkp.NewKDFParams()
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+	}
+
+	ctx := context.Background()
+	header, _, err := negotiate(ctx, grpcClient, cpzkpParams, protocol)
 	if err != nil {
-		log.Fatal(err)
+		log.Print(err)
 		return nil, err
 	}
 
-	// Get the secret value `x` by converting the password uniquely to big Int
-	x := getSecretValue(password)
+	// Get the secret value `x` by stretching the salted password
+	x, err := cp_zkp.DeriveSecret(password, kdf, cpzkpParams)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
 	log.Println("[grpcClient-Prover] Transformed password in to a secret value `x`")
 
 	// Create a new Prover (Client) based on the generated secret value `x`
-	// to calculate the y1 and y2 params
-	client := cp_zkp.NewProver(x)
+	// to calculate the y1 and y2 params. The nonce of the registration proof is hedged:
+	// it is derived from `x`, the registration context and fresh randomness.
+	client := newProver(cpzkp, x)
+
+	// Prover(client) generates y1 and y2 values and proves that they share the same
+	// exponent (or only y1 and the knowledge of its exponent for Schnorr), bound to the user name
+	y1, y2, proof, err := possessionProof(client, cpzkpParams, protocol, cp_zkp.RegistrationContext(user))
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
 
-	// Prover(client) generates y1 and y2 values
-	y1, y2 := client.GenerateYValues(cpzkpParams)
+	// The device-held secret is registered with its own proof
+	var deviceKey *api.DeviceKey
+	if device != nil {
+		deviceProver := newProver(cpzkp, device)
+
+		dy1, dy2 := deviceProver.GenerateYValues(cpzkpParams)
+		deviceProof, err := deviceProver.CreateNIProof(cpzkpParams, cp_zkp.RegistrationContext(user))
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+
+		deviceKey = &api.DeviceKey{Y1: dy1.Bytes(), Y2: dy2.Bytes(), Proof: proofToProto(deviceProof, cpzkpParams)}
+	}
 
 	// Received response
-	ctx := context.Background()
 	_, err = grpcClient.Register(
 		ctx,
 		&api.RegisterRequest{
-			User: user,
-			Y1:   y1.String(),
-			Y2:   y2.String(),
+			Header: header,
+			User:   user,
+			Y1:     y1,
+			Y2:     y2,
+			Kdf:    kdfToProto(kdf),
+			Proof:  proof,
+			Device: deviceKey,
 		},
 	)
 
@@ -98,46 +155,51 @@ func Register(grpcClient api.AuthClient, user, password string) (*RegRes, error)
 }
 
 // LogIn : Validates the login credentials using the Chaum-Pedersen Zero-Knowledge Proof
-// protocol and returns a succesful message for a valid login
-func LogIn(grpcClient api.AuthClient, user, password string) (*LogInRes, error) {
+// protocol and returns a succesful message for a valid login.
+// `cpzkp` selects the group and parameters, which must match the server's
+func LogIn(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, user, password string) (*LogInRes, error) {
+	return LogInWithDevice(grpcClient, cpzkp, user, password, nil)
+}
+
+// LogInWithDevice logs in to a two-factor account. The password-derived and the device-held
+// secret are proven in one interaction with a shared challenge (an AND proof). A nil
+// `device` logs in to a password-only account.
+func LogInWithDevice(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, user, password string, device *big.Int) (*LogInRes, error) {
 
 	// Generate the system parameters
-	cpzkp, err := cp_zkp.NewCPZKP()
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 
-	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	protocol, err := clientProtocol(cpzkp, device)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 
-	// Get the secret value `x` by converting the password uniquely to big Int
-	x := getSecretValue(password)
-
-	log.Println("[grpcClient-Prover] Retrieved secret value `x` from the input password")
+	ctx := context.Background()
+	header, serverID, err := negotiate(ctx, grpcClient, cpzkpParams, protocol)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
 
-	// Create a new Prover (Client) based on the generated secret value `x`
-	// to calculate the r1 and r2 params for committing the proof
-	client := cp_zkp.NewProver(x)
+	// Get the secret value `x` by stretching the salted password with the user's KDF params
+	x, err := loginSecret(ctx, grpcClient, cpzkpParams, header, user, password)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
 
-	k, r1, r2, err := client.CreateProofCommitment(cpzkpParams)
+	provers, k, challengeReq, err := commitLogin(cpzkp, cpzkpParams, header, serverID, user, x, device)
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 
-	ctx := context.Background()
-	recvAuthChallengeRes, err := grpcClient.CreateAuthenticationChallenge(
-		ctx,
-		&api.AuthenticationChallengeRequest{
-			User: user,
-			R1:   r1.String(),
-			R2:   r2.String(),
-		},
-	)
+	recvAuthChallengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
 
 	if err != nil {
 		log.Fatal(color.RedString(err.Error()))
@@ -145,29 +207,30 @@ func LogIn(grpcClient api.AuthClient, user, password string) (*LogInRes, error)
 	}
 
 	authID := recvAuthChallengeRes.AuthId
-	cStr := recvAuthChallengeRes.C
-	c, err := util.ParseBigInt(cStr, "c")
+	c, err := cpzkpParams.DecodeScalar(recvAuthChallengeRes.C, "c")
 	if err != nil {
 		log.Print(err)
 		return nil, err
 	}
 
-	// Challenge response
+	// Challenge response, the same challenge for both secrets
+	s := cp_zkp.CreateANDChallengeResponse(cpzkpParams, provers, k, c)
 
-	s := client.CreateProofChallengeResponse(k, c, cpzkpParams)
+	answerReq := &api.AuthenticationAnswerRequest{
+		Header: header,
+		AuthId: authID,
+		S:      cpzkpParams.EncodeScalar(s[0]),
+	}
+	if device != nil {
+		answerReq.DeviceS = cpzkpParams.EncodeScalar(s[1])
+	}
 
 	// Verification Step
-	verifyRes, err := grpcClient.VerifyAuthentication(
-		ctx,
-		&api.AuthenticationAnswerRequest{
-			AuthId: authID,
-			S:      s.String(),
-		},
-	)
+	verifyRes, err := grpcClient.VerifyAuthentication(ctx, answerReq)
 
 	if err != nil {
 		log.Fatal(color.RedString(err.Error()))
-		return nil, grpc_err.ErrInvalidChallengeResponse{S: s.String()}
+		return nil, grpc_err.ErrInvalidChallengeResponse{S: s[0].String()}
 	}
 
 	return &LogInRes{
@@ -176,8 +239,192 @@ func LogIn(grpcClient api.AuthClient, user, password string) (*LogInRes, error)
 
 }
 
-// getSecretValue gets the secret value `x` by converting the password uniquely to big Int
-func getSecretValue(password string) *big.Int {
-	// Get the secret value `x` by converting the password uniquely to big Int
-	return util.StringToUniqueBigInt(password)
+// clientProtocol returns the identification protocol selected by `cpzkp`.
+// Two-factor accounts use Chaum-Pedersen only.
+func clientProtocol(cpzkp *cp_zkp.CPZKP, device *big.Int) (string, error) {
+	protocol, err := cp_zkp.ParseProtocol(cpzkp.Protocol)
+	if err != nil {
+		return "", err
+	}
+	if protocol == cp_zkp.ProtocolSchnorr && device != nil {
+		return "", fmt.Errorf("two-factor accounts use the %s protocol", cp_zkp.ProtocolChaumPedersen)
+	}
+	return protocol, nil
+}
+
+// negotiate checks that the server serves protocol v3 over the group of `params` and accepts
+// `protocol`, and returns the header of the v3 requests and the identity of the server
+func negotiate(ctx context.Context, grpcClient api.AuthClient, params *cp_zkp.CPZKPParams, protocol string) (*api.Header, string, error) {
+	versions, err := grpcClient.GetVersions(ctx, &api.VersionsRequest{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !contains(versions.Versions, api.Version_VERSION_3) {
+		return nil, "", fmt.Errorf("server does not support %s, only %v", api.Version_VERSION_3, versions.Versions)
+	}
+
+	group := params.Group().Name()
+	if versions.Group != group {
+		return nil, "", fmt.Errorf("server runs over the %s group, not %s", versions.Group, group)
+	}
+
+	if !contains(versions.Protocols, protocol) {
+		return nil, "", fmt.Errorf("server does not accept the %s protocol, only %v", protocol, versions.Protocols)
+	}
+
+	return &api.Header{Version: api.Version_VERSION_3, Group: group, Protocol: protocol}, versions.ServerId, nil
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// loginSecret derives the secret `x` of `user` from `password` with the KDF params the server
+// stores for the account, so that the login commitment can be hedged with `x`
+func loginSecret(ctx context.Context, grpcClient api.AuthClient, params *cp_zkp.CPZKPParams, header *api.Header, user, password string) (*big.Int, error) {
+	kdfRes, err := grpcClient.GetKDFParams(ctx, &api.KDFParamsRequest{Header: header, User: user})
+	if err != nil {
+		return nil, err
+	}
+
+	if kdfRes.Kdf == nil {
+		return nil, fmt.Errorf("server returned no KDF parameters for user %s", user)
+	}
+
+	x, err := cp_zkp.DeriveSecret(password, kdfFromProto(kdfRes.Kdf), params)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("[grpcClient-Prover] Retrieved secret value `x` from the input password")
+	return x, nil
+}
+
+// commitLogin creates the commitments of a login, or of a credential rotation, under the
+// protocol of `header`. The nonce of the secret `x` is hedged with `x` and the login context,
+// and the device-held secret commits next to it with its own hedged nonce. The answer to the
+// challenge `c` is `cp_zkp.CreateANDChallengeResponse(params, provers, k, c)`.
+func commitLogin(cpzkp *cp_zkp.CPZKP, params *cp_zkp.CPZKPParams, header *api.Header, serverID, user string, x, device *big.Int) (
+	provers []*cp_zkp.Prover, k []*big.Int, req *api.AuthenticationChallengeRequest, err error) {
+
+	// Both secrets share the session value, which no other login of this client uses
+	session := loginSession()
+
+	prover := newProver(cpzkp, x)
+	prover.Context = cp_zkp.LoginContext(serverID, user, cp_zkp.LoginFactorPassword, session)
+	provers = []*cp_zkp.Prover{prover}
+	req = &api.AuthenticationChallengeRequest{Header: header, User: user}
+
+	// Schnorr accounts commit r = g^k only
+	if header.Protocol == cp_zkp.ProtocolSchnorr {
+		k0, r, err := provers[0].CreateSchnorrCommitment(params)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		req.R1 = r.Bytes()
+		return provers, []*big.Int{k0}, req, nil
+	}
+
+	if device != nil {
+		deviceProver := newProver(cpzkp, device)
+		deviceProver.Context = cp_zkp.LoginContext(serverID, user, cp_zkp.LoginFactorDevice, session)
+		provers = append(provers, deviceProver)
+	}
+
+	k, commitments, err := cp_zkp.CreateANDCommitment(params, provers...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	req.R1 = commitments[0].R1.Bytes()
+	req.R2 = commitments[0].R2.Bytes()
+	if device != nil {
+		req.Device = &api.Commitment{R1: commitments[1].R1.Bytes(), R2: commitments[1].R2.Bytes()}
+	}
+	return provers, k, req, nil
+}
+
+// loginCounter numbers the logins of this client, see `loginSession`
+var loginCounter atomic.Uint64
+
+// loginSession returns a value that no other login of this client uses: the current time and
+// a counter. It keeps the hedged nonces of two logins apart when the RNG repeats itself.
+func loginSession() []byte {
+	var session [16]byte
+	binary.BigEndian.PutUint64(session[:8], uint64(time.Now().UnixNano()))
+	binary.BigEndian.PutUint64(session[8:], loginCounter.Add(1))
+	return session[:]
+}
+
+// newProver returns a prover of `x` whose nonces are hedged and drawn from `cpzkp.Rand`
+func newProver(cpzkp *cp_zkp.CPZKP, x *big.Int) *cp_zkp.Prover {
+	prover := cp_zkp.NewProver(x)
+	prover.Rand = cpzkp.Rand
+	prover.Hedged = true
+	return prover
+}
+
+// possessionProof returns the public values of the prover's secret and the non-interactive
+// proof of possession bound to `context`, encoded for the wire. Schnorr accounts have no `y2`,
+// and their proof carries its commitment as `r1`.
+func possessionProof(prover *cp_zkp.Prover, params *cp_zkp.CPZKPParams, protocol string, context []byte) (
+	y1, y2 []byte, proof *api.Proof, err error) {
+
+	if protocol == cp_zkp.ProtocolSchnorr {
+		y := prover.GenerateSchnorrY(params)
+		schnorrProof, err := prover.CreateSchnorrNIProof(params, context)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return y.Bytes(), nil, &api.Proof{
+			R1: schnorrProof.R.Bytes(),
+			C:  params.EncodeScalar(schnorrProof.C),
+			S:  params.EncodeScalar(schnorrProof.S),
+		}, nil
+	}
+
+	Y1, Y2 := prover.GenerateYValues(params)
+	cpProof, err := prover.CreateNIProof(params, context)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return Y1.Bytes(), Y2.Bytes(), proofToProto(cpProof, params), nil
+}
+
+// kdfToProto converts the KDF params to their wire representation
+func kdfToProto(kdf *cp_zkp.KDFParams) *api.KDFParams {
+	return &api.KDFParams{
+		Algorithm:   kdf.Algorithm,
+		Salt:        kdf.Salt,
+		Time:        kdf.Time,
+		Memory:      kdf.Memory,
+		Parallelism: kdf.Parallelism,
+	}
+}
+
+// proofToProto converts a non-interactive proof to its wire representation
+func proofToProto(proof *cp_zkp.Proof, params *cp_zkp.CPZKPParams) *api.Proof {
+	return &api.Proof{
+		R1: proof.R1.Bytes(),
+		R2: proof.R2.Bytes(),
+		C:  params.EncodeScalar(proof.C),
+		S:  params.EncodeScalar(proof.S),
+	}
+}
+
+// kdfFromProto converts the KDF params received from the server
+func kdfFromProto(kdf *api.KDFParams) *cp_zkp.KDFParams {
+	return &cp_zkp.KDFParams{
+		Algorithm:   kdf.Algorithm,
+		Salt:        kdf.Salt,
+		Time:        kdf.Time,
+		Memory:      kdf.Memory,
+		Parallelism: kdf.Parallelism,
+	}
 }
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// NewDeviceKey generates a device-held secret: a uniform non-zero scalar for the params of `cpzkp`
+func NewDeviceKey(cpzkp *cp_zkp.CPZKP) (*big.Int, error) {
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	if err != nil {
+		return nil, err
+	}
+	return cp_zkp.RandomScalar(nil, cpzkpParams.Group().Order())
+}
+
+// WriteDeviceKey stores the device-held secret as hex in a new file readable by the owner
+// only. An existing file is never overwritten, so a registered key cannot be lost.
+func WriteDeviceKey(path string, device *big.Int) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(f, "%x\n", device); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadDeviceKey loads a device-held secret written by `WriteDeviceKey`
+func ReadDeviceKey(path string) (*big.Int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	device, ok := new(big.Int).SetString(strings.TrimSpace(string(data)), 16)
+	if !ok || device.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid device key in %s", path)
+	}
+	return device, nil
+}
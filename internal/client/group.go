@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	api "github.com/srinathLN7/zkp_auth/api/v3/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+type GroupLogInRes struct {
+	SessionId string `json:"session_id"`
+	Group     string `json:"group"`
+}
+
+// GroupLogIn : Logs in to `group` anonymously. The client proves with a disjunctive (OR)
+// Chaum-Pedersen proof that it knows the secret of one of the group's registered members,
+// and the server issues a session for the group without learning which member `user` is.
+// `cpzkp` selects the group and parameters, which must match the server's
+func GroupLogIn(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, group, user, password string) (*GroupLogInRes, error) {
+
+	// Generate the system parameters
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	ctx := context.Background()
+	header, serverID, err := negotiate(ctx, grpcClient, cpzkpParams, cp_zkp.ProtocolChaumPedersen)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Fetch the public values of every member: the proof runs over all of them
+	groupRes, err := grpcClient.GetGroup(ctx, &api.GroupRequest{Header: header, Group: group})
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	index := -1
+	users := make([]string, len(groupRes.Members))
+	statements := make([]cp_zkp.Statement, len(groupRes.Members))
+	for i, member := range groupRes.Members {
+		y1, err := cpzkpParams.DecodeElement(member.Y1, fmt.Sprintf("members[%d].y1", i))
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+
+		y2, err := cpzkpParams.DecodeElement(member.Y2, fmt.Sprintf("members[%d].y2", i))
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+
+		users[i] = member.User
+		statements[i] = cp_zkp.Statement{Y1: y1, Y2: y2}
+		if member.User == user {
+			index = i
+		}
+	}
+
+	if index < 0 || groupRes.Members[index].Kdf == nil {
+		err := fmt.Errorf("user %s is not a registered member of group %s", user, group)
+		log.Print(err)
+		return nil, err
+	}
+
+	// Get the secret value `x` by stretching the salted password. The nonce of the real
+	// branch is hedged with `x` and the login context.
+	x, err := cp_zkp.DeriveSecret(password, kdfFromProto(groupRes.Members[index].Kdf), cpzkpParams)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	prover := newProver(cpzkp, x)
+	prover.Context = cp_zkp.LoginContext(serverID, user, cp_zkp.LoginFactorPassword, loginSession())
+
+	state, commitments, err := prover.CreateORCommitment(cpzkpParams, statements, index)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	challengeReq := &api.GroupAuthenticationChallengeRequest{Header: header, Group: group, Users: users}
+	for _, commitment := range commitments {
+		challengeReq.Commitments = append(challengeReq.Commitments, &api.Commitment{
+			R1: commitment.R1.Bytes(),
+			R2: commitment.R2.Bytes(),
+		})
+	}
+
+	challengeRes, err := grpcClient.CreateGroupAuthenticationChallenge(ctx, challengeReq)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	c, err := cpzkpParams.DecodeScalar(challengeRes.C, "c")
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Challenge response: one (c_i, s_i) per member
+	answerReq := &api.GroupAuthenticationAnswerRequest{Header: header, AuthId: challengeRes.AuthId}
+	for _, t := range prover.CreateORChallengeResponse(state, c, cpzkpParams) {
+		answerReq.Responses = append(answerReq.Responses, &api.Response{
+			C: cpzkpParams.EncodeScalar(t.C),
+			S: cpzkpParams.EncodeScalar(t.S),
+		})
+	}
+
+	verifyRes, err := grpcClient.VerifyGroupAuthentication(ctx, answerReq)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	return &GroupLogInRes{
+		SessionId: verifyRes.SessionId,
+		Group:     verifyRes.Group,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	api "github.com/srinathLN7/zkp_auth/api/v3/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// ErrParamsChanged is returned when the fingerprint of the parameters fetched from the server
+// differs from the one pinned in the cache file. Remove the cache entry to accept them.
+var ErrParamsChanged = errors.New("server parameters changed")
+
+// cachedParams is the entry of a group in the parameter cache file. `Params` was validated
+// when it was pinned, and is validated again whenever the file is read.
+type cachedParams struct {
+	Fingerprint string              `json:"fingerprint"`
+	Params      *cp_zkp.CPZKPParams `json:"params"`
+}
+
+// FetchParams : Fetches the parameters of the group of `cpzkp` with `GetParameters`,
+// validates them, checks their fingerprint and makes `cpzkp` use them.
+// With a non-empty `cacheFile`, the parameters of every group are pinned in that file the
+// first time they are fetched. Afterwards the pinned copy is used as long as the server sends
+// the same fingerprint and encoding, and other parameters are refused with `ErrParamsChanged`.
+func FetchParams(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, cacheFile string) (*cp_zkp.CPZKPParams, error) {
+	group := cpzkp.Group
+	if group == "" {
+		group = cp_zkp.GroupModP
+	}
+
+	res, err := grpcClient.GetParameters(context.Background(), &api.ParametersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var set *api.ParameterSet
+	for _, s := range res.Sets {
+		if s.Group == group {
+			set = s
+			break
+		}
+	}
+	if set == nil {
+		return nil, fmt.Errorf("server has no parameters for the %s group", group)
+	}
+
+	cache := make(map[string]cachedParams)
+	if cacheFile != "" {
+		if cache, err = readParamCache(cacheFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var params *cp_zkp.CPZKPParams
+	if cached, ok := cache[group]; ok {
+		params, err = pinnedParams(cacheFile, group, cached, set)
+	} else {
+		params, err = decodeParamSet(group, set)
+		if err == nil && cacheFile != "" {
+			cache[group] = cachedParams{Fingerprint: hex.EncodeToString(set.Fingerprint), Params: params}
+			err = writeParamCache(cacheFile, cache)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cpzkp.SetParams(params)
+	log.Printf("[grpcClient-Prover] Using the %s parameters of the server (fingerprint: %x)", group, set.Fingerprint)
+	return params, nil
+}
+
+// decodeParamSet decodes and validates the parameters sent by the server, and checks their fingerprint
+func decodeParamSet(group string, set *api.ParameterSet) (*cp_zkp.CPZKPParams, error) {
+
+	// The encoding is validated as a whole, as for a parameter file
+	params, err := cp_zkp.DecodeParams(set.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameters from the server: %w", group, err)
+	}
+	if params.Group().Name() != group {
+		return nil, fmt.Errorf("server sent %s parameters for the %s group", params.Group().Name(), group)
+	}
+
+	if !bytes.Equal(params.Fingerprint(), set.Fingerprint) {
+		return nil, fmt.Errorf("fingerprint of the %s parameters does not match their encoding", group)
+	}
+	return params, nil
+}
+
+// pinnedParams returns the pinned parameters of `group` if the server sent the same ones.
+// The server's encoding is compared with the pinned copy instead of being decoded again.
+func pinnedParams(cacheFile, group string, cached cachedParams, set *api.ParameterSet) (*cp_zkp.CPZKPParams, error) {
+	if cached.Params == nil || cached.Params.Group().Name() != group ||
+		hex.EncodeToString(cached.Params.Fingerprint()) != cached.Fingerprint {
+		return nil, fmt.Errorf("invalid parameter cache %s: the %s entry does not match its fingerprint", cacheFile, group)
+	}
+
+	if cached.Fingerprint != hex.EncodeToString(set.Fingerprint) {
+		return nil, fmt.Errorf("%w: the %s parameters pinned in %s have fingerprint %s, the server sent %x",
+			ErrParamsChanged, group, cacheFile, cached.Fingerprint, set.Fingerprint)
+	}
+
+	encoded, err := cached.Params.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(encoded, set.Params) {
+		return nil, fmt.Errorf("fingerprint of the %s parameters does not match their encoding", group)
+	}
+	return cached.Params, nil
+}
+
+// readParamCache reads the pinned parameters of every group, none if the file does not exist
+func readParamCache(cacheFile string) (map[string]cachedParams, error) {
+	cache := make(map[string]cachedParams)
+
+	data, err := os.ReadFile(cacheFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &cache); err != nil {
+			return nil, fmt.Errorf("invalid parameter cache %s: %w", cacheFile, err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+	return cache, nil
+}
+
+// writeParamCache writes the pinned parameters of every group
+func writeParamCache(cacheFile string, cache map[string]cachedParams) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cacheFile, data, 0o600)
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"log"
+	"math/big"
+
+	api "github.com/srinathLN7/zkp_auth/api/v3/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// RotateCredential : Replaces the password of `user`. The client answers a login challenge
+// with the secret derived from `password` and, bound to the same challenge, proves
+// possession of the secret derived from `newPassword`. `kdf` selects how the new password is
+// stretched; nil uses Argon2id with a fresh salt and the default cost. `device` is the
+// device-held secret of a two-factor account, nil for a password-only account; it is kept.
+// `cpzkp` selects the group and parameters, which must match the server's, and the protocol
+// the account was registered with
+func RotateCredential(grpcClient api.AuthClient, cpzkp *cp_zkp.CPZKP, user, password, newPassword string, kdf *cp_zkp.KDFParams, device *big.Int) (*RegRes, error) {
+
+	// Generate the system parameters
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	if kdf == nil {
+		kdf, err = cp_zkp.NewKDFParams()
+		if err != nil {
+			log.Print(err)
+			return nil, err
+		}
+	}
+
+	protocol, err := clientProtocol(cpzkp, device)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	ctx := context.Background()
+	header, serverID, err := negotiate(ctx, grpcClient, cpzkpParams, protocol)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Derive the current secret `x` and commit as for a login
+	x, err := loginSecret(ctx, grpcClient, cpzkpParams, header, user, password)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	provers, k, challengeReq, err := commitLogin(cpzkp, cpzkpParams, header, serverID, user, x, device)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	c, err := cpzkpParams.DecodeScalar(challengeRes.C, "c")
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	// Answer the challenge with the current secret `x`
+	s := cp_zkp.CreateANDChallengeResponse(cpzkpParams, provers, k, c)
+
+	// Derive the new secret `x'` and prove possession of it, bound to the answered challenge
+	newX, err := cp_zkp.DeriveSecret(newPassword, kdf, cpzkpParams)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	log.Println("[grpcClient-Prover] Transformed the new password in to a secret value `x'`")
+
+	y1, y2, proof, err := possessionProof(newProver(cpzkp, newX), cpzkpParams, protocol, cp_zkp.RotationContext(user, challengeRes.AuthId, c))
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	rotateReq := &api.RotateCredentialRequest{
+		Header: header,
+		AuthId: challengeRes.AuthId,
+		S:      cpzkpParams.EncodeScalar(s[0]),
+		Y1:     y1,
+		Y2:     y2,
+		Kdf:    kdfToProto(kdf),
+		Proof:  proof,
+	}
+	if device != nil {
+		rotateReq.DeviceS = cpzkpParams.EncodeScalar(s[1])
+	}
+
+	if _, err := grpcClient.RotateCredential(ctx, rotateReq); err != nil {
+		log.Print(err)
+		return nil, err
+	}
+
+	return &RegRes{
+		Msg: " credential rotation successful ",
+	}, nil
+}
@@ -0,0 +1,53 @@
+package cp_zkp
+
+import (
+	"math/big"
+)
+
+// CreateANDCommitment starts a conjunctive (AND) proof of knowledge of several secrets, e.g.
+// a password-derived secret and a device-held secret. Every prover commits with its own
+// nonce; the returned nonces are kept by the caller and the commitments are sent to the
+// verifier, which answers with one challenge shared by all the secrets.
+// Hedged provers need their secret to commit, and hedge their nonce with their own `Context`.
+func CreateANDCommitment(params *CPZKPParams, provers ...*Prover) (k []*big.Int, commitments []Commitment, err error) {
+	k = make([]*big.Int, len(provers))
+	commitments = make([]Commitment, len(provers))
+	for i, p := range provers {
+		var r1, r2 Element
+		k[i], r1, r2, err = p.CreateProofCommitment(params)
+		if err != nil {
+			return nil, nil, err
+		}
+		commitments[i] = Commitment{R1: r1, R2: r2}
+	}
+	return k, commitments, nil
+}
+
+// CreateANDChallengeResponse answers the shared challenge `c` with s_i = (k_i - c * x_i) mod q
+// for every secret, in the order of the commitments
+func CreateANDChallengeResponse(params *CPZKPParams, provers []*Prover, k []*big.Int, c *big.Int) []*big.Int {
+	s := make([]*big.Int, len(provers))
+	for i, p := range provers {
+		s[i] = p.CreateProofChallengeResponse(k[i], c, params)
+	}
+	return s
+}
+
+// VerifyANDProof verifies that the prover knows the secrets of all the statements. Each
+// statement is checked as in `VerifyProof` against its commitment and response, with the
+// same challenge `c` for all of them, so a single three-move interaction proves every secret.
+func (v *Verifier) VerifyANDProof(params *CPZKPParams, statements []Statement, commitments []Commitment, c *big.Int, s []*big.Int) bool {
+	if len(statements) == 0 || len(commitments) != len(statements) || len(s) != len(statements) {
+		return false
+	}
+
+	// Every statement is checked, so the running time does not depend on which one fails
+	valid := true
+	for i, st := range statements {
+		if s[i] == nil {
+			return false
+		}
+		valid = v.VerifyProof(st.Y1, st.Y2, commitments[i].R1, commitments[i].R2, c, s[i], params) && valid
+	}
+	return valid
+}
@@ -0,0 +1,62 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestANDProof tests that a proof of two secrets under one challenge verifies only when
+// both secrets are known
+func TestANDProof(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			// A password-derived secret and a device-held secret
+			provers := []*Prover{NewProver(big.NewInt(1234)), NewProver(big.NewInt(5678))}
+			statements := make([]Statement, len(provers))
+			for i, p := range provers {
+				statements[i].Y1, statements[i].Y2 = p.GenerateYValues(params)
+			}
+
+			// The password prover commits before its secret is known
+			k, commitments, err := CreateANDCommitment(params, &Prover{}, provers[1])
+			if err != nil {
+				t.Fatalf("error creating AND commitment: %v", err)
+			}
+
+			verifier := Verifier{}
+			c, err := verifier.CreateProofChallenge(params)
+			if err != nil {
+				t.Fatalf("error creating challenge: %v", err)
+			}
+
+			s := CreateANDChallengeResponse(params, provers, k, c)
+			if !verifier.VerifyANDProof(params, statements, commitments, c, s) {
+				t.Fatalf("expected the AND proof to verify")
+			}
+
+			// Knowing only one of the secrets is not enough
+			wrong := CreateANDChallengeResponse(params, []*Prover{provers[0], NewProver(big.NewInt(7))}, k, c)
+			if verifier.VerifyANDProof(params, statements, commitments, c, wrong) {
+				t.Errorf("expected a proof with a wrong device secret to fail")
+			}
+
+			if verifier.VerifyANDProof(params, statements, commitments, new(big.Int).Add(c, big.NewInt(1)), s) {
+				t.Errorf("expected a proof for another challenge to fail")
+			}
+
+			if verifier.VerifyANDProof(params, statements[:1], commitments[:1], c, s) {
+				t.Errorf("expected a proof with a missing statement to fail")
+			}
+		})
+	}
+}
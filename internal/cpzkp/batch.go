@@ -0,0 +1,115 @@
+package cp_zkp
+
+import (
+	"io"
+	"log"
+	"math/big"
+)
+
+// Bit size of the random weights of the linear combination. A batch containing an
+// invalid proof passes with probability at most 2^-batchWeightBits.
+const batchWeightBits = 128
+
+// BatchItem is one interactive Chaum-Pedersen transcript (y1, y2, r1, r2, c, s) to verify
+type BatchItem struct {
+	Y1, Y2, R1, R2 Element
+	C, S           *big.Int
+}
+
+// VerifyBatch verifies many proofs together and returns, for each item, whether it is valid.
+//
+// All the checks r1_i = g^s_i . y1_i^c_i and r2_i = h^s_i . y2_i^c_i are combined with
+// independent random weights a_i and b_i into the single equation
+//
+//	prod(r1_i^a_i . r2_i^b_i) = g^sum(a_i.s_i) . h^sum(b_i.s_i) . prod(y1_i^(a_i.c_i) . y2_i^(b_i.c_i))
+//
+// whose two sides are computed with one multi-exponentiation each. If the combined check
+// fails, every item is verified on its own to find the failing proofs.
+func (v *Verifier) VerifyBatch(items []BatchItem, params *CPZKPParams) []bool {
+	results := make([]bool, len(items))
+	if len(items) == 0 {
+		return results
+	}
+
+	if v.verifyCombined(items, params) {
+		for i := range results {
+			results[i] = true
+		}
+		log.Printf("[grpcServer-Verifier]: Verified a batch of %d proofs", len(items))
+		return results
+	}
+
+	// Fall back to per-item checks to find the failing proofs
+	log.Printf("[grpcServer-Verifier]: Batch of %d proofs failed, verifying each proof", len(items))
+	for i, item := range items {
+		results[i] = v.VerifyProof(item.Y1, item.Y2, item.R1, item.R2, item.C, item.S, params)
+	}
+	return results
+}
+
+// verifyCombined runs the random linear combination check over all the items
+func (v *Verifier) verifyCombined(items []BatchItem, params *CPZKPParams) bool {
+	grp := params.group
+	q := grp.Order()
+
+	lhsBases := make([]Element, 0, 2*len(items))
+	lhsExps := make([]*big.Int, 0, 2*len(items))
+	rhsBases := []Element{params.g, params.h}
+	rhsExps := []*big.Int{new(big.Int), new(big.Int)}
+
+	for _, item := range items {
+		if !item.valid() || !inSubgroup(grp, item.Y1, item.Y2, item.R1, item.R2) {
+			return false
+		}
+
+		a, err := batchWeight(v.Rand)
+		if err != nil {
+			return false
+		}
+		b, err := batchWeight(v.Rand)
+		if err != nil {
+			return false
+		}
+
+		lhsBases = append(lhsBases, item.R1, item.R2)
+		lhsExps = append(lhsExps, a, b)
+
+		// sum(a_i.s_i) and sum(b_i.s_i) mod q
+		rhsExps[0].Add(rhsExps[0], new(big.Int).Mul(a, item.S)).Mod(rhsExps[0], q)
+		rhsExps[1].Add(rhsExps[1], new(big.Int).Mul(b, item.S)).Mod(rhsExps[1], q)
+
+		ac := new(big.Int).Mul(a, item.C)
+		bc := new(big.Int).Mul(b, item.C)
+		rhsBases = append(rhsBases, item.Y1, item.Y2)
+		rhsExps = append(rhsExps, ac.Mod(ac, q), bc.Mod(bc, q))
+	}
+
+	return grp.Equal(MultiExp(grp, lhsBases, lhsExps), MultiExp(grp, rhsBases, rhsExps))
+}
+
+func (item BatchItem) valid() bool {
+	return item.Y1 != nil && item.Y2 != nil && item.R1 != nil && item.R2 != nil && item.C != nil && item.S != nil
+}
+
+// batchWeight draws a uniform non-zero weight of `batchWeightBits` bits
+func batchWeight(random io.Reader) (*big.Int, error) {
+	return RandomScalar(random, new(big.Int).Lsh(big.NewInt(1), batchWeightBits))
+}
+
+// inSubgroup reports whether all the elements lie in the order `q` subgroup. The random
+// linear combination is only sound for such elements: e.g. mod a safe prime, -r1 would
+// pass the combined check whenever its weight is even.
+func inSubgroup(grp Group, elements ...Element) bool {
+	modp, ok := grp.(*ModPGroup)
+	if !ok {
+		// The curve groups have prime order, every decoded element is a member
+		return true
+	}
+
+	for _, e := range elements {
+		if !modp.contains(e.(modPElement).v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"testing"
+)
+
+// batchItems creates n valid interactive transcripts for distinct provers
+func batchItems(t *testing.T, params *CPZKPParams, n int) []BatchItem {
+	items := make([]BatchItem, n)
+	verifier := Verifier{}
+	for i := range items {
+		prover := NewProver(big.NewInt(int64(1000 + i)))
+		y1, y2 := prover.GenerateYValues(params)
+
+		k, r1, r2, err := prover.CreateProofCommitment(params)
+		if err != nil {
+			t.Fatalf("error creating commitment: %v", err)
+		}
+
+		c, err := verifier.CreateProofChallenge(params)
+		if err != nil {
+			t.Fatalf("error creating challenge: %v", err)
+		}
+
+		items[i] = BatchItem{Y1: y1, Y2: y2, R1: r1, R2: r2, C: c, S: prover.CreateProofChallengeResponse(k, c, params)}
+	}
+	return items
+}
+
+// TestVerifyBatch tests that a batch of valid proofs passes and that the invalid
+// proofs of a mixed batch are singled out
+func TestVerifyBatch(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			verifier := Verifier{}
+			items := batchItems(t, params, 8)
+
+			for i, valid := range verifier.VerifyBatch(items, params) {
+				if !valid {
+					t.Errorf("expected proof %d to be valid", i)
+				}
+			}
+
+			// Tamper with two of the proofs
+			items[2].S = new(big.Int).Add(items[2].S, big.NewInt(1))
+			items[5].R1 = params.Group().Mul(items[5].R1, params.G())
+
+			for i, valid := range verifier.VerifyBatch(items, params) {
+				if expected := i != 2 && i != 5; valid != expected {
+					t.Errorf("proof %d: expected valid=%v, got %v", i, expected, valid)
+				}
+			}
+
+			if len(verifier.VerifyBatch(nil, params)) != 0 {
+				t.Errorf("expected no results for an empty batch")
+			}
+		})
+	}
+}
+
+// TestVerifyBatchSubgroup tests that commitments outside the order `q` subgroup are
+// rejected, even though they may satisfy the weighted combined equation
+func TestVerifyBatchSubgroup(t *testing.T) {
+	cpZKP, err := NewCPZKP()
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	grp := params.Group().(*ModPGroup)
+	items := batchItems(t, params, 4)
+
+	// -r1 = (p-1) . r1 has order 2q
+	minusOne := grp.NewElement(new(big.Int).Sub(grp.P(), big.NewInt(1)))
+	items[1].R1 = grp.Mul(items[1].R1, minusOne)
+
+	verifier := Verifier{}
+	for i, valid := range verifier.VerifyBatch(items, params) {
+		if expected := i != 1; valid != expected {
+			t.Errorf("proof %d: expected valid=%v, got %v", i, expected, valid)
+		}
+	}
+}
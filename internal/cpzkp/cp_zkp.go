@@ -1,73 +1,250 @@
 package cp_zkp
 
 import (
-	"crypto/rand"
+	"fmt"
+	"io"
 	"log"
 	"math/big"
+	"sync"
 
 	"github.com/srinathLN7/zkp_auth/lib/config"
 	"github.com/srinathLN7/zkp_auth/lib/util"
 )
 
+// Domain separation seed used to derive the second generator `h` on the curves.
+// Anyone can re-derive `h` from this seed and check that log_g(h) is unknown.
+const generatorHSeed = "zkp_auth/cpzkp/generator-h"
+
 type CPZKP struct {
+	// Group selects the group the protocol runs over:
+	// `modp` (default), `p256` or `ristretto255`
+	Group string
+
+	// ParamFile optionally points to a `modp` parameter file written by `WriteParamFile`.
+	// Preset optionally names a built-in RFC 3526 / RFC 7919 `modp` group, e.g. `ffdhe2048`.
+	// When both are empty, the `ffdhe2048` preset is used.
+	ParamFile string
+	Preset    string
+
+	// Insecure opts in to `modp` groups below `MinModPBits`/`MinSubgroupBits`: parameter files
+	// are checked with the insecure floors, and the 255-bit sample parameters from the config
+	// file replace the default preset. It is meant for tests only.
+	Insecure bool
+
+	// Protocol selects the identification protocol: `chaum-pedersen` (default) or `schnorr`.
+	// A client registers and logs in with it. A server only accepts registrations with it,
+	// or with every protocol when it is empty.
+	Protocol string
+
+	// Rand is the source of the nonces of the client's provers. If nil, `crypto/rand` is used.
+	Rand io.Reader
+
+	// params caches the parameters built by `InitCPZKPParams` from `source`
+	mu     sync.Mutex
+	params *CPZKPParams
+	source paramSource
+}
+
+// paramSource is the selection of params cached by `InitCPZKPParams`
+type paramSource struct {
+	group, paramFile, preset string
+	insecure                 bool
 }
 
 // CPZKPParams represents the public parameters for the ZKP protocol.
-// p -> primeP, q -> primeQ, g -> generatorG, and h -> generatorH.
+// group -> prime order group of order `q`, g -> generatorG, and h -> generatorH.
 type CPZKPParams struct {
-	p, q, g, h *big.Int
+	group Group
+	g, h  Element
+
+	// constant-time arithmetic and fixed-base tables of `g` and `h`, built on first use
+	ctOnce sync.Once
+	ct     *ctParams
 }
 
 // Prover represents the prover in the ZKP protocol.
 type Prover struct {
 	x *big.Int // Secret number x
+
+	// Rand is the source of the nonces `k`. If nil, `crypto/rand` is used.
+	Rand io.Reader
+
+	// Hedged derives the nonces `k` from `x`, the context of the proof and the randomness of
+	// `Rand` with HMAC-DRBG, so that a broken or predictable RNG cannot leak `x`
+	Hedged bool
+
+	// Context is the context hedged into the nonces of the interactive commitments, e.g. a
+	// `LoginContext`. Non-interactive proofs are hedged with the context they are bound to.
+	Context []byte
 }
 
 // Verifier represents the verifier in the ZKP protocol.
 type Verifier struct {
+	// Rand is the source of the challenges, nonces and batch weights. If nil, `crypto/rand` is used.
+	Rand io.Reader
 }
 
 func NewCPZKP() (*CPZKP, error) {
-	return &CPZKP{}, nil
+	return &CPZKP{Group: GroupModP}, nil
+}
+
+// NewCPZKPWithGroup creates a CPZKP instance running over the named group
+func NewCPZKPWithGroup(group string) (*CPZKP, error) {
+	if _, err := NewGroup(group); err != nil {
+		return nil, err
+	}
+	return &CPZKP{Group: group}, nil
+}
+
+// SupportsProtocol reports whether accounts may be registered with the named protocol
+func (zkp *CPZKP) SupportsProtocol(name string) bool {
+	protocol, err := ParseProtocol(name)
+	if err != nil {
+		return false
+	}
+	if zkp.Protocol == "" {
+		return true
+	}
+	accepted, err := ParseProtocol(zkp.Protocol)
+	return err == nil && accepted == protocol
 }
 
 // InitCPZKPParams initializes the Chaum-Pedersen ZKP protocol system params.
+// For the `modp` group the `ffdhe2048` preset is used unless a parameter file or another
+// preset is selected, or `Insecure` selects the config file parameters. For the curves
+// `g` is the standard base point and `h` is derived from a public seed.
+// The params are built and validated once and then cached, together with their
+// fixed-base tables, until `Group`, `ParamFile`, `Preset` or `Insecure` change.
 func (zkp *CPZKP) InitCPZKPParams() (*CPZKPParams, error) {
+	zkp.mu.Lock()
+	defer zkp.mu.Unlock()
 
-	// Generate the system parameters from the config file
-	p, err := util.ParseBigInt(config.CPZKP_PARAM_P, "p")
+	source := paramSource{zkp.Group, zkp.ParamFile, zkp.Preset, zkp.Insecure}
+	if zkp.params != nil && zkp.source == source {
+		return zkp.params, nil
+	}
+
+	params, err := zkp.newParams()
 	if err != nil {
 		return nil, err
 	}
 
-	q, err := util.ParseBigInt(config.CPZKP_PARAM_Q, "q")
+	zkp.params, zkp.source = params, source
+	return params, nil
+}
+
+// SetParams makes `InitCPZKPParams` return `params`, e.g. parameters fetched from a server.
+// `Group` is set to their group, and `ParamFile` and `Preset` are cleared.
+func (zkp *CPZKP) SetParams(params *CPZKPParams) {
+	zkp.mu.Lock()
+	defer zkp.mu.Unlock()
+
+	zkp.Group, zkp.ParamFile, zkp.Preset = params.group.Name(), "", ""
+	zkp.params, zkp.source = params, paramSource{zkp.Group, "", "", zkp.Insecure}
+}
+
+// newParams builds the params selected by `Group`, `ParamFile`, `Preset` and `Insecure`
+func (zkp *CPZKP) newParams() (*CPZKPParams, error) {
+
+	name := zkp.Group
+	if name == "" {
+		name = GroupModP
+	}
+
+	if zkp.ParamFile != "" && zkp.Preset != "" {
+		return nil, fmt.Errorf("a parameter file and a preset cannot be used together")
+	}
+
+	if zkp.ParamFile != "" {
+		return zkp.loadParamFile(name)
+	}
+
+	if zkp.Preset != "" {
+		if name != GroupModP {
+			return nil, fmt.Errorf("parameter presets are only supported for the %s group", GroupModP)
+		}
+		return NewPresetParams(zkp.Preset)
+	}
+
+	if name == GroupModP && !zkp.Insecure {
+		return NewPresetParams(PresetFFDHE2048)
+	}
+
+	group, err := NewGroup(name)
 	if err != nil {
 		return nil, err
 	}
 
-	g, err := util.ParseBigInt(config.CPZKP_PARAM_G, "g")
+	var zkpParams *CPZKPParams
+	switch grp := group.(type) {
+	case *ModPGroup:
+		// Generate the sample system parameters from the config file, only with `Insecure`
+		h, err := util.ParseBigInt(config.CPZKP_PARAM_H, "h")
+		if err != nil {
+			return nil, err
+		}
+
+		zkpParams, err = NewModPParamsInsecure(grp.p, grp.q, grp.g, h)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		zkpParams = NewCPZKPParams(group, group.Generator(), group.HashToElement([]byte(generatorHSeed)))
+		if err := ValidateParams(zkpParams); err != nil {
+			return nil, err
+		}
+	}
+
+	// Log the system generated parameters to the console
+
+	log.Println("[ZKP_Auth] ------------------- Generated Chaum–Pedersen ZKP Protocol System Parameters ------------------- ")
+	log.Printf("{ \n group: %v, \n q: %v, \n g : %v, \n h : %v \n }", group.Name(), group.Order(), zkpParams.g, zkpParams.h)
+
+	return zkpParams, nil
+}
+
+// loadParamFile reads the verified parameter set from `ParamFile`
+func (zkp *CPZKP) loadParamFile(name string) (*CPZKPParams, error) {
+	if name != GroupModP {
+		return nil, fmt.Errorf("parameter files are only supported for the %s group", GroupModP)
+	}
+
+	paramFile, err := readParamFile(zkp.ParamFile, zkp.Insecure)
 	if err != nil {
 		return nil, err
 	}
 
-	h, err := util.ParseBigInt(config.CPZKP_PARAM_H, "h")
+	zkpParams, err := paramFile.cpzkpParams(zkp.Insecure)
 	if err != nil {
 		return nil, err
 	}
 
-	zkpParams := CPZKPParams{
-		p: p,
-		q: q,
-		g: g,
-		h: h,
+	log.Printf("[ZKP_Auth] Loaded %d-bit Chaum–Pedersen ZKP Protocol System Parameters from %s (seed: %s)", paramFile.Bits, zkp.ParamFile, paramFile.Seed)
+	return zkpParams, nil
+}
+
+// NewCPZKPParams creates the protocol parameters from a group and the two generators `g` and `h`
+func NewCPZKPParams(group Group, g, h Element) *CPZKPParams {
+	return &CPZKPParams{
+		group: group,
+		g:     g,
+		h:     h,
 	}
+}
 
-	// Log the system generated parameters to the console
+// Group returns the group the protocol runs over
+func (params *CPZKPParams) Group() Group {
+	return params.group
+}
 
-	log.Println("[ZKP_Auth] ------------------- Generated Chaum–Pedersen ZKP Protocol System Parameters ------------------- ")
-	log.Printf("{ \n p: %v, \n q: %v, \n g : %v, \n h : %v \n }", zkpParams.p, zkpParams.q, zkpParams.g, zkpParams.h)
+// G returns the generator `g`
+func (params *CPZKPParams) G() Element {
+	return params.g
+}
 
-	return &zkpParams, nil
+// H returns the generator `h`
+func (params *CPZKPParams) H() Element {
+	return params.h
 }
 
 // NewProver creates a new Prover with the given secret password x.
@@ -78,98 +255,86 @@ func NewProver(x *big.Int) *Prover {
 }
 
 // GenerateYValues generates y1 and y2 for the prover based on the public parameters.
-// The prover calculates y1 = g^x mod p and y2 = h^x mod p.
+// The prover calculates y1 = g^x and y2 = h^x.
 // y1 and y2 are public informations
-func (p *Prover) GenerateYValues(params *CPZKPParams) (y1, y2 *big.Int) {
-	y1 = new(big.Int).Exp(params.g, p.x, params.p)
-	y2 = new(big.Int).Exp(params.h, p.x, params.p)
+// The exponentiations run in constant time, so they do not leak the secret `x`.
+func (p *Prover) GenerateYValues(params *CPZKPParams) (y1, y2 Element) {
+	y1 = params.secretExpG(p.x)
+	y2 = params.secretExpH(p.x)
 	log.Println("[grpcClient-Prover]: Generated `y1` and `y2` values")
 	return y1, y2
 }
 
 // CreateProofCommitment: creates a zero-knowledge proof commitment step based on the prover's y1 and y2 values.
-// The prover selects a random value k and commits (r1, r2) = (g^k mod p, h^k mod p).
-func (p *Prover) CreateProofCommitment(params *CPZKPParams) (k, r1, r2 *big.Int, err error) {
+// The prover selects a random value k and commits (r1, r2) = (g^k, h^k).
+// A hedged `k` is derived from `x` and `Context`.
+func (p *Prover) CreateProofCommitment(params *CPZKPParams) (k *big.Int, r1, r2 Element, err error) {
+	return p.commit(params, p.Context)
+}
 
-	// Generate a random `c` in the range of [0, p) following uniform random distribution
-	k, err = rand.Int(rand.Reader, params.p)
+// commit creates the commitment of a proof bound to `context`, which only matters for hedged nonces
+func (p *Prover) commit(params *CPZKPParams, context []byte) (k *big.Int, r1, r2 Element, err error) {
+
+	k, err = p.nonce(params, context)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	// Additional check to ensure `k` is not zero for better security
-	// if `k` is zero then call the function again to ensure `k` is
-	// really a big integer -> RARE occurence
-	if k.Cmp(big.NewInt(0)) == 0 {
-		return p.CreateProofCommitment(params)
-	}
-
-	// Compute commitments (r1, r2) = (g^k mod p, h^k mod p)
-	r1 = new(big.Int).Exp(params.g, k, params.p)
-	r2 = new(big.Int).Exp(params.h, k, params.p)
+	// Compute commitments (r1, r2) = (g^k, h^k)
+	r1 = params.secretExpG(k)
+	r2 = params.secretExpH(k)
 
 	log.Println("[grpcClient-Prover]: Created proof commitment. Generated `k`, `r1` and `r2` values")
 	return k, r1, r2, nil
 }
 
+// nonce generates a uniform non-zero random `k` in Z_q, hedged with `x` and `context` if `Hedged` is set
+func (p *Prover) nonce(params *CPZKPParams, context []byte) (*big.Int, error) {
+	if p.Hedged {
+		return p.hedgedNonce(params, context)
+	}
+	return RandomScalar(p.Rand, params.group.Order())
+}
+
 // CreateProofChallenge: verifier creates a challenge to the prover by generating a random big integer
 // `c` which will be subsequently used by the prover in the `CreateProofChallengeResponse` step
 func (v *Verifier) CreateProofChallenge(params *CPZKPParams) (c *big.Int, err error) {
 
-	// Generate a random `c` in the range of [0, p) following uniform random distribution
-	c, err = rand.Int(rand.Reader, params.p)
+	// Generate a uniform non-zero random `c` in Z_q
+	c, err = RandomScalar(v.Rand, params.group.Order())
 	if err != nil {
 		return nil, err
 	}
 
-	// Additional check to ensure c is not zero for better security
-	// if `c` is zero then call the function again to ensure `c` is
-	// really a big integer -> RARE occurence
-	if c.Cmp(big.NewInt(0)) == 0 {
-		return v.CreateProofChallenge(params)
-	}
-
 	log.Println("[grpcServer-Verifier]: Created proof challenge. Generated `c` value")
 	return c, nil
 }
 
 // CreateProofChallengeResponse: prover creates the response to the verifier's challenge
 // Compute s = (k - c * x) mod q
+// `k` and `x` are secret, so the arithmetic runs in constant time in Montgomery form.
 func (p *Prover) CreateProofChallengeResponse(k, c *big.Int, params *CPZKPParams) (s *big.Int) {
-	s = new(big.Int).Sub(k, new(big.Int).Mul(c, p.x))
-	s.Mod(s, params.q)
+	ct := params.constantTime()
+	q := ct.q
+	s = q.toCanonical(q.sub(q.fromBig(k), q.mul(q.fromBig(c), q.fromBig(p.x)))).toBig()
 
 	log.Println("[grpcClient-Prover]: Created proof response. Computed `s` value")
 	return s
 }
 
 // VerifyProof verifies the zero-knowledge proof using the verifier's y1, y2, and the public parameters.
-// The verifier checks if r1 = (g^s * y1^c) mod p and r2 = (h^s * y2^c) mod p.
+// The verifier checks if r1 = g^s * y1^c and r2 = h^s * y2^c.
 // If both checks pass, the proof is valid, and the function returns true; otherwise, it returns false.
-func (v *Verifier) VerifyProof(y1, y2, r1, r2, c, s *big.Int, params *CPZKPParams) bool {
+func (v *Verifier) VerifyProof(y1, y2, r1, r2 Element, c, s *big.Int, params *CPZKPParams) bool {
 
 	defer log.Println("[grpcServer-Verifier]: Verified the generated proof")
 
-	// Debug logs
-	// log.Printf("[cp_zkp] y1 = %v", y1)
-	// log.Printf("[cp_zkp] y2 = %v", y2)
-	// log.Printf("[cp_zkp] r1 = %v", r1)
-	// log.Printf("[cp_zkp] r2 = %v", r2)
-	// log.Printf("[cp_zkp] c = %v", c)
-	// log.Printf("[cp_zkp] s = %v", s)
-
-	// Remember: (ab) mod p = ( (a mod p) (b mod p)) mod p
-	l1 := new(big.Int).Exp(params.g, s, params.p) // g^s .mod p
-	l1.Mul(l1, new(big.Int).Exp(y1, c, params.p)) // y1^c mod p
-	l1.Mod(l1, params.p)                          // (g^s mod p) (y1 ^c mod p) mod p = (g^s . y1^c). mod p
-
-	if l1.Cmp(r1) != 0 {
-		return false
-	}
+	// g^s . y1^c
+	l1 := params.expMul(params.g, gTable, s, y1, c)
 
-	l2 := new(big.Int).Exp(params.h, s, params.p)
-	l2.Mul(l2, new(big.Int).Exp(y2, c, params.p))
-	l2.Mod(l2, params.p)
+	// h^s . y2^c
+	l2 := params.expMul(params.h, hTable, s, y2, c)
 
-	return l2.Cmp(r2) == 0
+	// Both checks are always evaluated and compared in constant time
+	return ctEqual(l1, r1) && ctEqual(l2, r2)
 }
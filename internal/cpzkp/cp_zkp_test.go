@@ -69,6 +69,63 @@ func TestCPZKPProtocol(t *testing.T) {
 	}
 }
 
+// TestCPZKPProtocolGroups tests the correctness and soundness of the
+// CP-ZKP protocol over every supported group.
+func TestCPZKPProtocolGroups(t *testing.T) {
+
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			x, err := util.ParseBigInt(sys_config.CPZKP_TEST_X_CORRECT, "x")
+			if err != nil {
+				t.Fatalf("error parsing the secret value `x` to big integer")
+			}
+
+			prover := NewProver(x)
+			y1, y2 := prover.GenerateYValues(params)
+			k, r1, r2, err := prover.CreateProofCommitment(params)
+			if err != nil {
+				t.Fatalf("error creating proof commitment: %v", err)
+			}
+
+			verifier := Verifier{}
+			c, err := verifier.CreateProofChallenge(params)
+			if err != nil {
+				t.Fatalf("error creating challenge: %v", err)
+			}
+
+			s := prover.CreateProofChallengeResponse(k, c, params)
+
+			// Elements must survive the round trip through their wire encoding
+			y1, err = params.ParseElement(y1.String(), "y1")
+			if err != nil {
+				t.Fatalf("error parsing y1: %v", err)
+			}
+
+			// Test Correctness
+			if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+				t.Fatalf("proof validation failed: expected valid proof, got invalid")
+			}
+
+			// Test Soundness
+			invalidS := new(big.Int).Add(s, big.NewInt(1))
+			if verifier.VerifyProof(y1, y2, r1, r2, c, invalidS, params) {
+				t.Fatalf("proof validation failed: expected invalid proof, got valid")
+			}
+		})
+	}
+}
+
 // Run the tests
 func TestMain(m *testing.M) {
 	m.Run()
@@ -0,0 +1,383 @@
+package cp_zkp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Version byte leading the binary encoding of `CPZKPParams`
+const paramsEncodingVersion = 1
+
+// Domain separation tag of the parameter fingerprint
+const fingerprintDST = "zkp_auth/cpzkp/params-fingerprint/v1"
+
+// ErrNoParams is returned when a `Proof` is encoded or decoded without the parameters that
+// fix the sizes of its elements and scalars. Use `CPZKPParams.NewProof` to decode a proof.
+var ErrNoParams = errors.New("proof has no parameters")
+
+// textEncoding is the text form of the binary encodings: unpadded base64url, decoded strictly
+// so that every value has exactly one text form
+var textEncoding = base64.RawURLEncoding.Strict()
+
+// ElementSize returns the length of the canonical binary encoding of a non-identity element
+func (params *CPZKPParams) ElementSize() int {
+	return len(params.g.Bytes())
+}
+
+// ScalarSize returns the length of the binary encoding of a scalar: the byte length of `q`
+func (params *CPZKPParams) ScalarSize() int {
+	return (params.group.Order().BitLen() + 7) / 8
+}
+
+// EncodeScalar returns the fixed-length big-endian encoding of a scalar in Z_q
+func (params *CPZKPParams) EncodeScalar(s *big.Int) []byte {
+	return new(big.Int).Mod(s, params.group.Order()).FillBytes(make([]byte, params.ScalarSize()))
+}
+
+// DecodeScalar parses the encoding returned by `EncodeScalar`. It only accepts exactly
+// `ScalarSize` bytes holding a value in [0, q-1]. Failures are reported as `ErrInvalidInput`.
+func (params *CPZKPParams) DecodeScalar(b []byte, field string) (*big.Int, error) {
+	if len(b) != params.ScalarSize() {
+		return nil, ErrInvalidInput{Field: field, Reason: fmt.Errorf("%w: scalar is %d bytes, expected %d", ErrNonCanonical, len(b), params.ScalarSize())}
+	}
+
+	v := new(big.Int).SetBytes(b)
+	if v.Cmp(params.group.Order()) >= 0 {
+		return nil, ErrInvalidInput{Field: field, Reason: fmt.Errorf("%w: scalar is not in [0, q-1]", ErrOutOfRange)}
+	}
+	return v, nil
+}
+
+// DecodeElement parses the canonical binary encoding returned by `Element.Bytes` with the
+// same checks as `ParseElement`: canonical encoding, order `q` subgroup, never the identity
+func (params *CPZKPParams) DecodeElement(b []byte, field string) (Element, error) {
+	e, err := params.group.Decode(b)
+	if err != nil {
+		if !errors.Is(err, ErrOutOfRange) {
+			err = fmt.Errorf("%w: %v", ErrNonCanonical, err)
+		}
+		return nil, ErrInvalidInput{Field: field, Reason: err}
+	}
+
+	if !bytes.Equal(e.Bytes(), b) {
+		return nil, ErrInvalidInput{Field: field, Reason: ErrNonCanonical}
+	}
+
+	return params.checkElement(e, field)
+}
+
+// MarshalBinary encodes the parameters as a version byte followed by the length-prefixed group
+// name, `p` (for `modp` only), `q`, `g` and `h`. It is the encoding proofs are bound to.
+func (params *CPZKPParams) MarshalBinary() ([]byte, error) {
+	return append([]byte{paramsEncodingVersion}, params.encode()...), nil
+}
+
+// UnmarshalBinary decodes and validates parameters encoded by `MarshalBinary` into
+// zero-value params. Use `DecodeParams` to get new params instead.
+func (params *CPZKPParams) UnmarshalBinary(b []byte) error {
+	if params.group != nil {
+		return errors.New("cannot unmarshal into initialized params")
+	}
+
+	decoded, err := DecodeParams(b)
+	if err != nil {
+		return err
+	}
+
+	params.group, params.g, params.h = decoded.group, decoded.g, decoded.h
+	return nil
+}
+
+// DecodeParams decodes the encoding returned by `MarshalBinary`. The parameters are validated
+// as by `NewModPParams` or `ValidateParams`, and any other encoding of them is rejected.
+func DecodeParams(b []byte) (*CPZKPParams, error) {
+	if len(b) == 0 || b[0] != paramsEncodingVersion {
+		return nil, fmt.Errorf("%w: unsupported params encoding version", ErrNonCanonical)
+	}
+
+	r := &lpReader{b: b[1:]}
+	name := string(r.next())
+
+	var params *CPZKPParams
+	var err error
+	switch name {
+	case GroupModP:
+		p, q := new(big.Int).SetBytes(r.next()), new(big.Int).SetBytes(r.next())
+		g, h := new(big.Int).SetBytes(r.next()), new(big.Int).SetBytes(r.next())
+		if r.err != nil {
+			return nil, r.err
+		}
+		params, err = NewModPParams(p, q, g, h)
+	default:
+		params, err = decodeCurveParams(name, r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Leading zeros, trailing bytes or a non-canonical element encoding all change the re-encoding
+	if encoded, _ := params.MarshalBinary(); !bytes.Equal(encoded, b) {
+		return nil, fmt.Errorf("%w: params encoding", ErrNonCanonical)
+	}
+	return params, nil
+}
+
+// decodeCurveParams decodes `q`, `g` and `h` of a prime-order curve group. `q` is fixed by the group.
+func decodeCurveParams(name string, r *lpReader) (*CPZKPParams, error) {
+	group, err := NewGroup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	q, gb, hb := r.next(), r.next(), r.next()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if new(big.Int).SetBytes(q).Cmp(group.Order()) != 0 {
+		return nil, ErrInvalidParams{Param: "q", Reason: fmt.Errorf("%w: not the order of the %s group", ErrParamOutOfRange, name)}
+	}
+
+	g, err := group.Decode(gb)
+	if err != nil {
+		return nil, ErrInvalidParams{Param: "g", Reason: err}
+	}
+
+	h, err := group.Decode(hb)
+	if err != nil {
+		return nil, ErrInvalidParams{Param: "h", Reason: err}
+	}
+
+	params := NewCPZKPParams(group, g, h)
+	if err := ValidateParams(params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// Fingerprint returns the SHA-256 of a domain separation tag and the `MarshalBinary` encoding.
+// Equal parameters have the same fingerprint on every machine, so clients can pin it.
+func (params *CPZKPParams) Fingerprint() []byte {
+	encoded, _ := params.MarshalBinary()
+	sum := sha256.Sum256(append(appendLP(nil, []byte(fingerprintDST)), encoded...))
+	return sum[:]
+}
+
+// MarshalText encodes the binary form in unpadded base64url
+func (params *CPZKPParams) MarshalText() ([]byte, error) {
+	b, err := params.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(textEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes the text form returned by `MarshalText`
+func (params *CPZKPParams) UnmarshalText(text []byte) error {
+	b, err := textEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonCanonical, err)
+	}
+	return params.UnmarshalBinary(b)
+}
+
+// paramsJSON is the JSON form of `CPZKPParams`. The values are the unpadded base64url encodings
+// of the big-endian integers `p` and `q` and of the elements `g` and `h`.
+type paramsJSON struct {
+	Group string `json:"group"`
+	P     string `json:"p,omitempty"`
+	Q     string `json:"q"`
+	G     string `json:"g"`
+	H     string `json:"h"`
+}
+
+// MarshalJSON encodes the parameters as a `paramsJSON` object
+func (params *CPZKPParams) MarshalJSON() ([]byte, error) {
+	v := paramsJSON{
+		Group: params.group.Name(),
+		Q:     textEncoding.EncodeToString(params.group.Order().Bytes()),
+		G:     textEncoding.EncodeToString(params.g.Bytes()),
+		H:     textEncoding.EncodeToString(params.h.Bytes()),
+	}
+	if grp, ok := params.group.(*ModPGroup); ok {
+		v.P = textEncoding.EncodeToString(grp.p.Bytes())
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes and validates the object returned by `MarshalJSON`
+func (params *CPZKPParams) UnmarshalJSON(data []byte) error {
+	var v paramsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	fields := []string{v.Q, v.G, v.H}
+	if v.Group == GroupModP {
+		fields = append([]string{v.P}, fields...)
+	} else if v.P != "" {
+		return fmt.Errorf("%w: p is only used by the %s group", ErrNonCanonical, GroupModP)
+	}
+
+	// Rebuild the binary encoding, which is then decoded and validated as a whole
+	b := []byte{paramsEncodingVersion}
+	b = appendLP(b, []byte(v.Group))
+	for _, field := range fields {
+		value, err := textEncoding.DecodeString(field)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrNonCanonical, err)
+		}
+		b = appendLP(b, value)
+	}
+	return params.UnmarshalBinary(b)
+}
+
+// MarshalBinary encodes the proof as r1 || r2 || c || s: two elements of `ElementSize` and
+// two scalars of `ScalarSize` bytes under the parameters the proof was created with
+func (proof *Proof) MarshalBinary() ([]byte, error) {
+	if proof.params == nil {
+		return nil, ErrNoParams
+	}
+	if proof.R1 == nil || proof.R2 == nil || proof.C == nil || proof.S == nil {
+		return nil, errors.New("incomplete proof")
+	}
+
+	b := append(proof.R1.Bytes(), proof.R2.Bytes()...)
+	b = append(b, proof.params.EncodeScalar(proof.C)...)
+	return append(b, proof.params.EncodeScalar(proof.S)...), nil
+}
+
+// UnmarshalBinary decodes the encoding returned by `MarshalBinary` into a proof created by
+// `CPZKPParams.NewProof`. Every element and scalar is checked as by `DecodeElement` and `DecodeScalar`.
+func (proof *Proof) UnmarshalBinary(b []byte) error {
+	params := proof.params
+	if params == nil {
+		return ErrNoParams
+	}
+
+	es, ss := params.ElementSize(), params.ScalarSize()
+	if len(b) != 2*es+2*ss {
+		return ErrInvalidInput{Field: "proof", Reason: fmt.Errorf("%w: proof is %d bytes, expected %d", ErrNonCanonical, len(b), 2*es+2*ss)}
+	}
+
+	r1, err := params.DecodeElement(b[:es], "r1")
+	if err != nil {
+		return err
+	}
+
+	r2, err := params.DecodeElement(b[es:2*es], "r2")
+	if err != nil {
+		return err
+	}
+
+	c, err := params.DecodeScalar(b[2*es:2*es+ss], "c")
+	if err != nil {
+		return err
+	}
+
+	s, err := params.DecodeScalar(b[2*es+ss:], "s")
+	if err != nil {
+		return err
+	}
+
+	proof.R1, proof.R2, proof.C, proof.S = r1, r2, c, s
+	return nil
+}
+
+// MarshalText encodes the binary form in unpadded base64url
+func (proof *Proof) MarshalText() ([]byte, error) {
+	b, err := proof.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(textEncoding.EncodeToString(b)), nil
+}
+
+// UnmarshalText decodes the text form returned by `MarshalText`
+func (proof *Proof) UnmarshalText(text []byte) error {
+	b, err := textEncoding.DecodeString(string(text))
+	if err != nil {
+		return ErrInvalidInput{Field: "proof", Reason: fmt.Errorf("%w: %v", ErrNonCanonical, err)}
+	}
+	return proof.UnmarshalBinary(b)
+}
+
+// proofJSON is the JSON form of `Proof`, with every value in unpadded base64url
+type proofJSON struct {
+	R1 string `json:"r1"`
+	R2 string `json:"r2"`
+	C  string `json:"c"`
+	S  string `json:"s"`
+}
+
+// MarshalJSON encodes the proof as a `proofJSON` object
+func (proof *Proof) MarshalJSON() ([]byte, error) {
+	b, err := proof.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	es, ss := proof.params.ElementSize(), proof.params.ScalarSize()
+	return json.Marshal(proofJSON{
+		R1: textEncoding.EncodeToString(b[:es]),
+		R2: textEncoding.EncodeToString(b[es : 2*es]),
+		C:  textEncoding.EncodeToString(b[2*es : 2*es+ss]),
+		S:  textEncoding.EncodeToString(b[2*es+ss:]),
+	})
+}
+
+// UnmarshalJSON decodes the object returned by `MarshalJSON` into a proof created by `CPZKPParams.NewProof`
+func (proof *Proof) UnmarshalJSON(data []byte) error {
+	var v proofJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var b []byte
+	for _, field := range []struct{ value, name string }{{v.R1, "r1"}, {v.R2, "r2"}, {v.C, "c"}, {v.S, "s"}} {
+		value, err := textEncoding.DecodeString(field.value)
+		if err != nil {
+			return ErrInvalidInput{Field: field.name, Reason: fmt.Errorf("%w: %v", ErrNonCanonical, err)}
+		}
+		b = append(b, value...)
+	}
+	return proof.UnmarshalBinary(b)
+}
+
+// NewProof returns an empty proof to decode a proof created under these parameters into
+func (params *CPZKPParams) NewProof() *Proof {
+	return &Proof{params: params}
+}
+
+// lpReader reads the length-prefixed fields written by `appendLP`
+type lpReader struct {
+	b   []byte
+	err error
+}
+
+func (r *lpReader) next() []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < 4 || uint64(len(r.b)-4) < uint64(binary.BigEndian.Uint32(r.b)) {
+		r.err = fmt.Errorf("%w: truncated params encoding", ErrNonCanonical)
+		return nil
+	}
+
+	n := binary.BigEndian.Uint32(r.b)
+	field := r.b[4 : 4+n]
+	r.b = r.b[4+n:]
+	return field
+}
+
+// appendLP appends a field with its 4-byte big-endian length prefix
+func appendLP(b, field []byte) []byte {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(field)))
+	return append(append(b, l[:]...), field...)
+}
@@ -0,0 +1,243 @@
+package cp_zkp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
+	"github.com/srinathLN7/zkp_auth/lib/util"
+)
+
+// TestEncodingRoundTrip tests that parameters and proofs survive the binary, text and JSON
+// encodings unchanged over every group, and that decoded proofs still verify
+func TestEncodingRoundTrip(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			params := encodingTestParams(t, group)
+
+			bin, err := params.MarshalBinary()
+			if err != nil {
+				t.Fatalf("error encoding params: %v", err)
+			}
+
+			decoded, err := DecodeParams(bin)
+			if err != nil {
+				t.Fatalf("error decoding params: %v", err)
+			}
+			if again, _ := decoded.MarshalBinary(); !bytes.Equal(again, bin) {
+				t.Fatalf("params changed in a binary round trip")
+			}
+
+			text, err := params.MarshalText()
+			if err != nil {
+				t.Fatalf("error encoding params as text: %v", err)
+			}
+			var fromText CPZKPParams
+			if err := fromText.UnmarshalText(text); err != nil {
+				t.Fatalf("error decoding params from text: %v", err)
+			}
+			if again, _ := fromText.MarshalBinary(); !bytes.Equal(again, bin) {
+				t.Fatalf("params changed in a text round trip")
+			}
+
+			js, err := json.Marshal(params)
+			if err != nil {
+				t.Fatalf("error encoding params as JSON: %v", err)
+			}
+			var fromJSON CPZKPParams
+			if err := json.Unmarshal(js, &fromJSON); err != nil {
+				t.Fatalf("error decoding params from JSON: %v", err)
+			}
+			if again, _ := fromJSON.MarshalBinary(); !bytes.Equal(again, bin) {
+				t.Fatalf("params changed in a JSON round trip")
+			}
+
+			x, err := util.ParseBigInt(sys_config.CPZKP_TEST_X_CORRECT, "x")
+			if err != nil {
+				t.Fatalf("error parsing the secret value `x` to big integer")
+			}
+
+			prover := NewProver(x)
+			y1, y2 := prover.GenerateYValues(params)
+			context := []byte("message-id: 42")
+			proof, err := prover.CreateNIProof(params, context)
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+
+			proofBin, err := proof.MarshalBinary()
+			if err != nil {
+				t.Fatalf("error encoding proof: %v", err)
+			}
+			if len(proofBin) != 2*params.ElementSize()+2*params.ScalarSize() {
+				t.Fatalf("expected a proof of %d bytes, got %d", 2*params.ElementSize()+2*params.ScalarSize(), len(proofBin))
+			}
+
+			proofText, err := proof.MarshalText()
+			if err != nil {
+				t.Fatalf("error encoding proof as text: %v", err)
+			}
+
+			proofJS, err := json.Marshal(proof)
+			if err != nil {
+				t.Fatalf("error encoding proof as JSON: %v", err)
+			}
+
+			verifier := Verifier{}
+			for name, decode := range map[string]func(*Proof) error{
+				"binary": func(p *Proof) error { return p.UnmarshalBinary(proofBin) },
+				"text":   func(p *Proof) error { return p.UnmarshalText(proofText) },
+				"json":   func(p *Proof) error { return json.Unmarshal(proofJS, p) },
+			} {
+				// Decoding under the decoded params must give the same proof
+				decodedProof := fromJSON.NewProof()
+				if err := decode(decodedProof); err != nil {
+					t.Fatalf("error decoding proof from %s: %v", name, err)
+				}
+				if !verifier.VerifyNIProof(y1, y2, decodedProof, context, params) {
+					t.Errorf("expected proof decoded from %s to verify", name)
+				}
+			}
+		})
+	}
+}
+
+// TestEncodingRejectsNonCanonical tests that every value has exactly one accepted encoding
+func TestEncodingRejectsNonCanonical(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			params := encodingTestParams(t, group)
+			q := params.group.Order()
+			ss := params.ScalarSize()
+
+			// Scalars: exact length, value below q
+			if _, err := params.DecodeScalar(params.EncodeScalar(big.NewInt(7)), "s"); err != nil {
+				t.Errorf("expected scalar to decode, got %v", err)
+			}
+			if _, err := params.DecodeScalar(big.NewInt(7).Bytes(), "s"); !errors.Is(err, ErrNonCanonical) {
+				t.Errorf("expected short scalar to be non-canonical, got %v", err)
+			}
+			if _, err := params.DecodeScalar(append([]byte{0}, params.EncodeScalar(big.NewInt(7))...), "s"); !errors.Is(err, ErrNonCanonical) {
+				t.Errorf("expected zero-padded scalar to be non-canonical, got %v", err)
+			}
+			if _, err := params.DecodeScalar(q.FillBytes(make([]byte, ss)), "s"); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("expected q to be out of range, got %v", err)
+			}
+
+			// Elements: exact canonical encoding, never the identity
+			g := params.g.Bytes()
+			if _, err := params.DecodeElement(g, "y1"); err != nil {
+				t.Errorf("expected g to decode, got %v", err)
+			}
+			if _, err := params.DecodeElement(append([]byte{0}, g...), "y1"); err == nil {
+				t.Errorf("expected element with a leading byte to be rejected")
+			}
+			if _, err := params.DecodeElement(g[1:], "y1"); err == nil {
+				t.Errorf("expected truncated element to be rejected")
+			}
+			if _, err := params.DecodeElement(params.group.Identity().Bytes(), "y1"); err == nil {
+				t.Errorf("expected the identity to be rejected")
+			}
+
+			// Params: no trailing bytes, no other version, no re-initialisation
+			bin, _ := params.MarshalBinary()
+			if _, err := DecodeParams(append(bin, 0)); !errors.Is(err, ErrNonCanonical) {
+				t.Errorf("expected params with trailing bytes to be non-canonical, got %v", err)
+			}
+			if _, err := DecodeParams(bin[:len(bin)-1]); err == nil {
+				t.Errorf("expected truncated params to be rejected")
+			}
+			wrongVersion := append([]byte{paramsEncodingVersion + 1}, bin[1:]...)
+			if _, err := DecodeParams(wrongVersion); !errors.Is(err, ErrNonCanonical) {
+				t.Errorf("expected unknown encoding version to be rejected, got %v", err)
+			}
+			if err := params.UnmarshalBinary(bin); err == nil {
+				t.Errorf("expected unmarshalling into initialized params to fail")
+			}
+
+			// Text: strict unpadded base64url only
+			text, _ := params.MarshalText()
+			var padded CPZKPParams
+			if err := padded.UnmarshalText(append(text, '=')); err == nil {
+				t.Errorf("expected padded base64 to be rejected")
+			}
+
+			// Proofs: fixed length, decoded under known params only
+			x, _ := util.ParseBigInt(sys_config.CPZKP_TEST_X_CORRECT, "x")
+			proof, err := NewProver(x).CreateNIProof(params, nil)
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+			proofBin, _ := proof.MarshalBinary()
+
+			if err := params.NewProof().UnmarshalBinary(proofBin[1:]); !errors.Is(err, ErrNonCanonical) {
+				t.Errorf("expected truncated proof to be non-canonical, got %v", err)
+			}
+			if err := (&Proof{}).UnmarshalBinary(proofBin); !errors.Is(err, ErrNoParams) {
+				t.Errorf("expected decoding without params to fail with ErrNoParams, got %v", err)
+			}
+			if _, err := (&Proof{R1: proof.R1, R2: proof.R2, C: proof.C, S: proof.S}).MarshalBinary(); !errors.Is(err, ErrNoParams) {
+				t.Errorf("expected encoding without params to fail with ErrNoParams, got %v", err)
+			}
+
+			outOfRange := append([]byte{}, proofBin...)
+			q.FillBytes(outOfRange[len(outOfRange)-ss:])
+			if err := params.NewProof().UnmarshalBinary(outOfRange); !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("expected proof with s = q to be out of range, got %v", err)
+			}
+		})
+	}
+}
+
+func encodingTestParams(t *testing.T, group string) *CPZKPParams {
+	t.Helper()
+
+	cpZKP, err := NewCPZKPWithGroup(group)
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+	return params
+}
+
+// TestParamsFingerprint tests that the fingerprint only depends on the parameters
+func TestParamsFingerprint(t *testing.T) {
+	fingerprints := make(map[string]string)
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		params := encodingTestParams(t, group)
+
+		bin, _ := params.MarshalBinary()
+		decoded, err := DecodeParams(bin)
+		if err != nil {
+			t.Fatalf("error decoding params: %v", err)
+		}
+
+		fingerprint := params.Fingerprint()
+		if len(fingerprint) != 32 {
+			t.Fatalf("expected a 32-byte fingerprint, got %d bytes", len(fingerprint))
+		}
+		if !bytes.Equal(decoded.Fingerprint(), fingerprint) {
+			t.Errorf("%s: expected decoded params to have the same fingerprint", group)
+		}
+
+		if other, ok := fingerprints[string(fingerprint)]; ok {
+			t.Errorf("%s and %s params have the same fingerprint", group, other)
+		}
+		fingerprints[string(fingerprint)] = group
+	}
+
+	preset, err := NewPresetParams(PresetMODP2048)
+	if err != nil {
+		t.Fatalf("error loading preset: %v", err)
+	}
+	if _, ok := fingerprints[string(preset.Fingerprint())]; ok {
+		t.Errorf("expected the %s preset to have its own fingerprint", PresetMODP2048)
+	}
+}
@@ -0,0 +1,55 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Errors reported when two transcripts cannot be combined into the witness
+var (
+	ErrSameChallenge       = errors.New("transcripts with the same challenge reveal nothing")
+	ErrNotAccepting        = errors.New("transcript is not accepted by the verifier")
+	ErrDifferentCommitment = errors.New("transcripts do not share the same commitment")
+)
+
+// ExtractSecret recovers the secret `x` from two accepting transcripts that share the
+// commitment (r1, r2), i.e. the same nonce `k`, but answer different challenges c1 and c2.
+// From s1 = k - c1*x and s2 = k - c2*x mod q it follows that
+//
+//	x = (s1 - s2) / (c2 - c1) mod q
+//
+// This is the special soundness of the protocol: it shows why a nonce must never be reused,
+// and why the server refuses a commitment it has already issued a challenge for.
+func ExtractSecret(params *CPZKPParams, c1, s1, c2, s2 *big.Int) (*big.Int, error) {
+	q := params.group.Order()
+
+	dc := new(big.Int).Sub(c2, c1)
+	dc.Mod(dc, q)
+	if dc.Sign() == 0 {
+		return nil, ErrSameChallenge
+	}
+
+	x := new(big.Int).Sub(s1, s2)
+	x.Mul(x, dc.ModInverse(dc, q))
+	return x.Mod(x, q), nil
+}
+
+// ExtractWitness is the special-soundness extractor of the protocol: from two accepting
+// transcripts for (y1, y2) that share the commitment but not the challenge, it recovers
+// the witness `x` with y1 = g^x and y2 = h^x. A prover able to answer two challenges for
+// one commitment therefore knows `x`.
+func ExtractWitness(params *CPZKPParams, y1, y2 Element, t1, t2 *ProofTranscript) (*big.Int, error) {
+	verifier := Verifier{}
+	for _, t := range []*ProofTranscript{t1, t2} {
+		if !verifier.VerifyProof(y1, y2, t.R1, t.R2, t.C, t.S, params) {
+			return nil, ErrNotAccepting
+		}
+	}
+
+	grp := params.group
+	if !grp.Equal(t1.R1, t2.R1) || !grp.Equal(t1.R2, t2.R2) {
+		return nil, ErrDifferentCommitment
+	}
+
+	return ExtractSecret(params, t1.C, t1.S, t2.C, t2.S)
+}
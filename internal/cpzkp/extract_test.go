@@ -0,0 +1,132 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// TestExtractSecret shows that answering two challenges for the same commitment leaks `x`
+func TestExtractSecret(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			x := big.NewInt(123456789)
+			prover := NewProver(x)
+			y1, y2 := prover.GenerateYValues(params)
+
+			// One commitment, two challenges
+			k, r1, r2, err := prover.CreateProofCommitment(params)
+			if err != nil {
+				t.Fatalf("error creating proof commitment: %v", err)
+			}
+
+			verifier := Verifier{}
+			var c, s [2]*big.Int
+			for i := range c {
+				c[i], err = verifier.CreateProofChallenge(params)
+				if err != nil {
+					t.Fatalf("error creating challenge: %v", err)
+				}
+				s[i] = prover.CreateProofChallengeResponse(k, c[i], params)
+
+				if !verifier.VerifyProof(y1, y2, r1, r2, c[i], s[i], params) {
+					t.Fatalf("expected transcript %d to verify", i)
+				}
+			}
+
+			extracted, err := ExtractSecret(params, c[0], s[0], c[1], s[1])
+			if err != nil {
+				t.Fatalf("error extracting secret: %v", err)
+			}
+			if extracted.Cmp(x) != 0 {
+				t.Errorf("expected to extract x = %v, got %v", x, extracted)
+			}
+			if !params.Group().Equal(params.Group().Exp(params.G(), extracted), y1) {
+				t.Errorf("expected g^x to equal y1")
+			}
+
+			if _, err := ExtractSecret(params, c[0], s[0], c[0], s[0]); !errors.Is(err, ErrSameChallenge) {
+				t.Errorf("expected ErrSameChallenge, got %v", err)
+			}
+		})
+	}
+}
+
+// TestExtractWitness checks over random secrets that the extractor recovers the witness
+// from any two accepting transcripts sharing a commitment, and rejects the others
+func TestExtractWitness(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			verifier := Verifier{}
+			for i := 0; i < 8; i++ {
+				x, err := RandomScalar(nil, params.Group().Order())
+				if err != nil {
+					t.Fatalf("error sampling secret: %v", err)
+				}
+				prover := NewProver(x)
+				y1, y2 := prover.GenerateYValues(params)
+
+				k, r1, r2, err := prover.CreateProofCommitment(params)
+				if err != nil {
+					t.Fatalf("error creating proof commitment: %v", err)
+				}
+
+				var transcripts [2]*ProofTranscript
+				for j := range transcripts {
+					c, err := verifier.CreateProofChallenge(params)
+					if err != nil {
+						t.Fatalf("error creating challenge: %v", err)
+					}
+					transcripts[j] = &ProofTranscript{R1: r1, R2: r2, C: c, S: prover.CreateProofChallengeResponse(k, c, params)}
+				}
+
+				extracted, err := ExtractWitness(params, y1, y2, transcripts[0], transcripts[1])
+				if err != nil {
+					t.Fatalf("error extracting witness: %v", err)
+				}
+				if extracted.Cmp(x) != 0 {
+					t.Fatalf("expected to extract x = %v, got %v", x, extracted)
+				}
+			}
+
+			// Simulated transcripts accept but never share a commitment with different challenges
+			y1, y2 := NewProver(big.NewInt(42)).GenerateYValues(params)
+			sim1, err := SimulateTranscript(params, y1, y2, nil)
+			if err != nil {
+				t.Fatalf("error simulating transcript: %v", err)
+			}
+			sim2, err := SimulateTranscript(params, y1, y2, nil)
+			if err != nil {
+				t.Fatalf("error simulating transcript: %v", err)
+			}
+			if _, err := ExtractWitness(params, y1, y2, sim1, sim2); !errors.Is(err, ErrDifferentCommitment) {
+				t.Errorf("expected ErrDifferentCommitment, got %v", err)
+			}
+
+			forged := &ProofTranscript{R1: sim1.R1, R2: sim1.R2, C: sim2.C, S: sim2.S}
+			if _, err := ExtractWitness(params, y1, y2, sim1, forged); !errors.Is(err, ErrNotAccepting) {
+				t.Errorf("expected ErrNotAccepting, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cp_zkp
+
+import (
+	"log"
+	"math/big"
+)
+
+// Domain separation tags for the Fiat-Shamir challenge derivation and for the
+// registration and rotation proof-of-possession contexts
+const (
+	fiatShamirDST   = "zkp_auth/cpzkp/fiat-shamir/v1"
+	registrationDST = "zkp_auth/cpzkp/register/v1"
+	rotationDST     = "zkp_auth/cpzkp/rotate/v1"
+	loginDST        = "zkp_auth/cpzkp/login/v1"
+)
+
+// Factors of a login, told apart in `LoginContext`
+const (
+	LoginFactorPassword = "password"
+	LoginFactorDevice   = "device"
+)
+
+// Proof is a self-contained non-interactive Chaum-Pedersen proof that log_g(y1) = log_h(y2).
+// The challenge `c` is derived by hashing the transcript instead of being sent by a verifier,
+// so the proof can be stored, attached to messages and verified later without a round trip.
+type Proof struct {
+	R1, R2 Element
+	C, S   *big.Int
+
+	// params fixes the binary encoding of the proof, see `MarshalBinary`
+	params *CPZKPParams
+}
+
+// CreateNIProof creates a non-interactive proof of knowledge of `x` bound to `context`.
+// The prover commits (r1, r2) = (g^k, h^k), derives c = H(params, y1, y2, r1, r2, context)
+// and responds with s = (k - c * x) mod q.
+func (p *Prover) CreateNIProof(params *CPZKPParams, context []byte) (*Proof, error) {
+	k, r1, r2, err := p.commit(params, context)
+	if err != nil {
+		return nil, err
+	}
+
+	y1 := params.secretExpG(p.x)
+	y2 := params.secretExpH(p.x)
+	c := fiatShamirChallenge(params, y1, y2, r1, r2, context)
+
+	return &Proof{
+		R1: r1,
+		R2: r2,
+		C:  c,
+		S:  p.CreateProofChallengeResponse(k, c, params),
+
+		params: params,
+	}, nil
+}
+
+// VerifyNIProof verifies a non-interactive proof against the prover's public y1 and y2.
+// It re-derives the challenge from the transcript and then runs the same checks as `VerifyProof`.
+func (v *Verifier) VerifyNIProof(y1, y2 Element, proof *Proof, context []byte, params *CPZKPParams) bool {
+	if proof == nil || proof.R1 == nil || proof.R2 == nil || proof.C == nil || proof.S == nil {
+		return false
+	}
+
+	c := fiatShamirChallenge(params, y1, y2, proof.R1, proof.R2, context)
+	if c.Cmp(proof.C) != 0 {
+		log.Println("[grpcServer-Verifier]: Fiat-Shamir challenge mismatch")
+		return false
+	}
+
+	return v.VerifyProof(y1, y2, proof.R1, proof.R2, c, proof.S, params)
+}
+
+// RegistrationContext returns the context of the proof sent with a registration. Binding the
+// proof to the user name keeps it from being replayed to register (y1, y2) under another name.
+func RegistrationContext(user string) []byte {
+	return newContext(registrationDST, []byte(user))
+}
+
+// RotationContext returns the context of the proof of possession sent with a credential rotation.
+// It binds the proof for the new public values to the user, the authentication session and the
+// challenge `c` answered with the current secret, so both proofs stand or fall together.
+func RotationContext(user, authID string, c *big.Int) []byte {
+	return newContext(rotationDST, []byte(user), []byte(authID), c.Bytes())
+}
+
+// LoginContext returns the context the nonce of a login commitment is hedged with: the server
+// identity, the user, the factor whose secret commits and `session`, a value the client never
+// uses twice. Two logins then get distinct nonces even from an RNG that repeats itself.
+func LoginContext(serverID, user, factor string, session []byte) []byte {
+	return newContext(loginDST, []byte(serverID), []byte(user), []byte(factor), session)
+}
+
+// newContext encodes a domain separation tag and the fields of a context, each with its length
+// as in `Transcript`, so that two different sequences of fields never give the same context
+func newContext(dst string, fields ...[]byte) []byte {
+	context := appendLP(nil, []byte(dst))
+	for _, field := range fields {
+		context = appendLP(context, field)
+	}
+	return context
+}
+
+// fiatShamirChallenge derives the challenge from a domain-separated transcript of the
+// parameters, the public values, the commitments and the context string
+func fiatShamirChallenge(params *CPZKPParams, y1, y2, r1, r2 Element, context []byte) *big.Int {
+	t := NewTranscript(fiatShamirDST)
+	t.AppendParams(params)
+	t.AppendElements("y", y1, y2)
+	t.AppendElements("r", r1, r2)
+	t.AppendMessage("context", context)
+	return t.Challenge("c", params.group.Order())
+}
+
+// encode returns an unambiguous encoding of the public parameters used to bind proofs to them
+func (params *CPZKPParams) encode() []byte {
+	var buf []byte
+	write := func(b []byte) {
+		buf = appendLP(buf, b)
+	}
+
+	write([]byte(params.group.Name()))
+	if grp, ok := params.group.(*ModPGroup); ok {
+		write(grp.p.Bytes())
+	}
+	write(params.group.Order().Bytes())
+	write(params.g.Bytes())
+	write(params.h.Bytes())
+	return buf
+}
@@ -0,0 +1,105 @@
+package cp_zkp
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
+	"github.com/srinathLN7/zkp_auth/lib/util"
+)
+
+// TestNIProof tests that non-interactive proofs verify offline and are bound to
+// the prover's public values and to the context string
+func TestNIProof(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			x, err := util.ParseBigInt(sys_config.CPZKP_TEST_X_CORRECT, "x")
+			if err != nil {
+				t.Fatalf("error parsing the secret value `x` to big integer")
+			}
+
+			prover := NewProver(x)
+			y1, y2 := prover.GenerateYValues(params)
+
+			context := []byte("message-id: 42")
+			proof, err := prover.CreateNIProof(params, context)
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+
+			verifier := Verifier{}
+			if !verifier.VerifyNIProof(y1, y2, proof, context, params) {
+				t.Fatalf("expected valid proof, got invalid")
+			}
+
+			// The proof is bound to the context string
+			if verifier.VerifyNIProof(y1, y2, proof, []byte("message-id: 43"), params) {
+				t.Errorf("expected proof to fail under a different context")
+			}
+
+			// The proof is bound to the prover's public values
+			otherY1, otherY2 := NewProver(big.NewInt(7)).GenerateYValues(params)
+			if verifier.VerifyNIProof(otherY1, otherY2, proof, context, params) {
+				t.Errorf("expected proof to fail for other public values")
+			}
+
+			// A tampered response is rejected
+			tampered := *proof
+			tampered.S = new(big.Int).Add(proof.S, big.NewInt(1))
+			if verifier.VerifyNIProof(y1, y2, &tampered, context, params) {
+				t.Errorf("expected tampered proof to fail")
+			}
+
+			if verifier.VerifyNIProof(y1, y2, nil, context, params) {
+				t.Errorf("expected missing proof to fail")
+			}
+		})
+	}
+}
+
+// TestContexts tests that the contexts proofs and nonces are bound to tell their fields apart,
+// even when a field holds the separator bytes of another encoding
+func TestContexts(t *testing.T) {
+	c := big.NewInt(0x0102)
+	rotations := [][]byte{
+		RotationContext("alice\x00bob", "auth", c),
+		RotationContext("alice", "bob\x00auth", c),
+		RotationContext("alice", "auth", c),
+		RotationContext("alice", "auth", big.NewInt(0x01)),
+	}
+
+	logins := [][]byte{
+		LoginContext("server\x00alice", "bob", LoginFactorPassword, []byte("session")),
+		LoginContext("server", "alice\x00bob", LoginFactorPassword, []byte("session")),
+		LoginContext("server", "alice", LoginFactorPassword+"\x00session", nil),
+		LoginContext("server", "alice", LoginFactorPassword, []byte("session")),
+	}
+
+	registrations := [][]byte{
+		RegistrationContext("alice"),
+		RegistrationContext("alice\x00"),
+		RegistrationContext("alice\x00\x00"),
+	}
+
+	for name, contexts := range map[string][][]byte{"rotation": rotations, "login": logins, "registration": registrations} {
+		for i := range contexts {
+			for j := i + 1; j < len(contexts); j++ {
+				if bytes.Equal(contexts[i], contexts[j]) {
+					t.Errorf("%s contexts %d and %d are equal", name, i, j)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cp_zkp
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// Smallest modulus for which the mod-p generators get full fixed-base (comb) tables.
+// Below this size a windowed exponentiation is as fast and needs far less memory.
+const fixedBaseMinModPBits = 1024
+
+// fixedBase holds precomputed powers of a mod-p generator in Montgomery form:
+// rows[i][d] = base^(d * 2^(w*i)) for every window i of the exponent and every
+// window digit d. With the full comb an exponentiation costs one table scan and one
+// multiplication per window and no squarings at all. Small moduli only keep rows[0],
+// the window table of a fixed-window exponentiation.
+type fixedBase struct {
+	rows [][]nat
+}
+
+// newFixedBase precomputes the tables of `base` for exponents of `ebits` bits
+func newFixedBase(pm *montModulus, base *big.Int, ebits int, comb bool) *fixedBase {
+	b := pm.fromBig(base)
+	if !comb {
+		return &fixedBase{rows: [][]nat{pm.windowTable(b)}}
+	}
+
+	rows := make([][]nat, (ebits+multiExpWindow-1)/multiExpWindow)
+	for i := range rows {
+		rows[i] = pm.windowTable(b)
+		// base^(2^w) for the next window
+		b = pm.mul(rows[i][len(rows[i])-1], b)
+	}
+	return &fixedBase{rows: rows}
+}
+
+// exp computes base^e in Montgomery form, in constant time
+func (fb *fixedBase) exp(pm *montModulus, e nat, ebits int) nat {
+	if len(fb.rows) == 1 {
+		return pm.exp(fb.rows[0], e, ebits)
+	}
+
+	acc := append(nat(nil), pm.one...)
+	for i, row := range fb.rows {
+		acc = pm.mul(acc, ctLookup(row, natDigit(e, i)))
+	}
+	return acc
+}
+
+// ctParams holds the constant-time view of the params: the scalar field mod `q` and,
+// for the mod-p groups, the modulus `p` and the tables of `g` and `h`
+type ctParams struct {
+	q     *montModulus
+	qbits int
+
+	p    *montModulus
+	comb bool
+	g, h *fixedBase
+
+	// fastG is set when `g` is the standard generator of a curve with precomputed tables for it
+	fastG bool
+}
+
+// generatorExper is implemented by the curves with a fixed-base multiplication of their
+// standard generator, which is faster than `Exp`
+type generatorExper interface {
+	expGenerator(k *big.Int) Element
+}
+
+// gTable and hTable select the tables of `g` and `h`
+func gTable(ct *ctParams) *fixedBase { return ct.g }
+func hTable(ct *ctParams) *fixedBase { return ct.h }
+
+// constantTime returns the constant-time arithmetic of the params, building it on first use
+func (params *CPZKPParams) constantTime() *ctParams {
+	params.ctOnce.Do(func() {
+		q := params.group.Order()
+		ct := &ctParams{q: newMontModulus(q), qbits: q.BitLen()}
+
+		if grp, ok := params.group.(*ModPGroup); ok {
+			ct.p = newMontModulus(grp.p)
+			ct.comb = grp.p.BitLen() >= fixedBaseMinModPBits
+			ct.g = newFixedBase(ct.p, params.g.(modPElement).v, ct.qbits, ct.comb)
+			ct.h = newFixedBase(ct.p, params.h.(modPElement).v, ct.qbits, ct.comb)
+		}
+		if _, ok := params.group.(generatorExper); ok {
+			ct.fastG = params.group.Equal(params.g, params.group.Generator())
+		}
+		params.ct = ct
+	})
+	return params.ct
+}
+
+// scalar reduces k mod q into fixed-width limbs without leaking its value
+func (ct *ctParams) scalar(k *big.Int) nat {
+	return ct.q.toCanonical(ct.q.fromBig(k))
+}
+
+// secretExpG computes g^k for a secret exponent, e.g. the secret `x` or the nonce `k`
+func (params *CPZKPParams) secretExpG(k *big.Int) Element {
+	return params.secretExp(params.g, k, gTable)
+}
+
+// secretExpH computes h^k for a secret exponent
+func (params *CPZKPParams) secretExpH(k *big.Int) Element {
+	return params.secretExp(params.h, k, hTable)
+}
+
+// secretExp uses the constant-time Montgomery backend for the mod-p groups. The scalar
+// multiplications of P-256 and ristretto255 are constant-time already, and use the
+// tables of the curve for its standard generator.
+func (params *CPZKPParams) secretExp(base Element, k *big.Int, table func(*ctParams) *fixedBase) Element {
+	ct := params.constantTime()
+	grp, ok := params.group.(*ModPGroup)
+	if !ok {
+		if ct.fastG && base == params.g {
+			return params.group.(generatorExper).expGenerator(k)
+		}
+		return params.group.Exp(base, k)
+	}
+
+	v := table(ct).exp(ct.p, ct.scalar(k), ct.qbits)
+	return grp.element(ct.p.toCanonical(v).toBig())
+}
+
+// publicExp computes base^k for a public exponent: the comb tables or the generator tables
+// of a curve are used when the group has them, otherwise the variable-time `Exp` of the group
+func (params *CPZKPParams) publicExp(base Element, k *big.Int, table func(*ctParams) *fixedBase) Element {
+	ct := params.constantTime()
+	if _, ok := params.group.(*ModPGroup); ok && ct.comb || ct.fastG && base == params.g {
+		return params.secretExp(base, k, table)
+	}
+	return params.group.Exp(base, k)
+}
+
+// expMul computes the two-base product base^s . y^c of the verification equations, where
+// all the values are public. Only ristretto255 computes it in one simultaneous (Straus)
+// pass. A Straus loop written in Go on the mod-p groups or P-256 is about twice as slow as
+// the assembly behind `big.Int.Exp` and the P-256 scalar multiplication, so there the
+// fixed base uses its comb or generator tables and y^c is a separate `Exp`.
+func (params *CPZKPParams) expMul(base Element, table func(*ctParams) *fixedBase, s *big.Int, y Element, c *big.Int) Element {
+	if _, ok := params.group.(multiExper); ok {
+		return MultiExp(params.group, []Element{base, y}, []*big.Int{s, c})
+	}
+	return params.group.Mul(params.publicExp(base, s, table), params.group.Exp(y, c))
+}
+
+// ctEqual compares the canonical encodings of two elements in constant time
+func ctEqual(a, b Element) bool {
+	return subtle.ConstantTimeCompare(a.Bytes(), b.Bytes()) == 1
+}
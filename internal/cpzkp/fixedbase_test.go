@@ -0,0 +1,202 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"testing"
+)
+
+// TestFixedBase checks the constant-time windowed and comb exponentiations of the
+// mod-p generators, and the multi-exponentiation, against plain exponentiations
+func TestFixedBase(t *testing.T) {
+	cpZKP := &CPZKP{Group: GroupModP, Insecure: true}
+	small, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	large, err := NewPresetParams(PresetFFDHE2048)
+	if err != nil {
+		t.Fatalf("error loading preset: %v", err)
+	}
+
+	for name, params := range map[string]*CPZKPParams{"window": small, "comb": large} {
+		t.Run(name, func(t *testing.T) {
+			grp := params.Group()
+			if comb := params.constantTime().comb; comb != (name == "comb") {
+				t.Fatalf("unexpected comb setting %v", comb)
+			}
+
+			q := grp.Order()
+			exps := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(16), new(big.Int).Sub(q, big.NewInt(1)), q,
+				new(big.Int).Lsh(q, 300)}
+			for i := 0; i < 4; i++ {
+				k, err := rand.Int(rand.Reader, q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				exps = append(exps, k)
+			}
+
+			for _, k := range exps {
+				if !grp.Equal(params.secretExpG(k), grp.Exp(params.G(), k)) {
+					t.Errorf("constant-time g^%v does not match Exp", k)
+				}
+				if !grp.Equal(params.secretExpH(k), grp.Exp(params.H(), k)) {
+					t.Errorf("constant-time h^%v does not match Exp", k)
+				}
+
+				c := new(big.Int).Add(k, big.NewInt(12345))
+				expected := grp.Mul(grp.Exp(params.G(), k), grp.Exp(params.H(), c))
+				if !grp.Equal(MultiExp(grp, []Element{params.G(), params.H()}, []*big.Int{k, c}), expected) {
+					t.Errorf("MultiExp does not match Exp for k = %v", k)
+				}
+			}
+		})
+	}
+}
+
+// TestInitCPZKPParamsCached checks that the params are built once and rebuilt when the source changes
+func TestInitCPZKPParamsCached(t *testing.T) {
+	cpZKP := &CPZKP{Group: GroupModP, Insecure: true}
+	params1, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	params2, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	if params1 != params2 {
+		t.Errorf("expected the cached params to be returned")
+	}
+
+	cpZKP.Preset = PresetFFDHE2048
+	params3, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	if params3 == params1 || params3.Group().Order().Cmp(params1.Group().Order()) == 0 {
+		t.Errorf("expected new params after changing the preset")
+	}
+
+	// Without the opt-in the config parameters are replaced by the default preset
+	cpZKP.Preset, cpZKP.Insecure = "", false
+	params4, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	if params4 != params3 {
+		t.Errorf("expected the %s preset by default", PresetFFDHE2048)
+	}
+}
+
+type benchmarkParamSet struct {
+	name   string
+	params *CPZKPParams
+}
+
+// benchmarkParams lists the parameter sets the benchmarks run over, from the smallest to
+// the largest. The protocol logs are silenced for the duration of the benchmark.
+func benchmarkParams(b *testing.B) []benchmarkParamSet {
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	var sets []benchmarkParamSet
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		cpZKP, err := NewCPZKPWithGroup(group)
+		if err != nil {
+			b.Fatal(err)
+		}
+		cpZKP.Insecure = true
+		params, err := cpZKP.InitCPZKPParams()
+		if err != nil {
+			b.Fatal(err)
+		}
+		sets = append(sets, benchmarkParamSet{group, params})
+	}
+
+	for _, name := range []string{PresetFFDHE2048, PresetFFDHE3072, PresetFFDHE4096} {
+		params, err := NewPresetParams(name)
+		if err != nil {
+			b.Fatal(err)
+		}
+		sets = append(sets, benchmarkParamSet{name, params})
+	}
+	return sets
+}
+
+// BenchmarkVerifyProof compares `VerifyProof` against the plain four exponentiations
+// it replaces, for each parameter size
+func BenchmarkVerifyProof(b *testing.B) {
+	for _, set := range benchmarkParams(b) {
+		name, params := set.name, set.params
+		prover := NewProver(big.NewInt(123456789))
+		y1, y2 := prover.GenerateYValues(params)
+		k, r1, r2, err := prover.CreateProofCommitment(params)
+		if err != nil {
+			b.Fatal(err)
+		}
+		c, err := rand.Int(rand.Reader, params.group.Order())
+		if err != nil {
+			b.Fatal(err)
+		}
+		s := prover.CreateProofChallengeResponse(k, c, params)
+
+		grp := params.group
+		b.Run(name+"/plain", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				l1 := grp.Mul(grp.Exp(params.g, s), grp.Exp(y1, c))
+				l2 := grp.Mul(grp.Exp(params.h, s), grp.Exp(y2, c))
+				if !grp.Equal(l1, r1) || !grp.Equal(l2, r2) {
+					b.Fatal("invalid proof")
+				}
+			}
+		})
+
+		verifier := Verifier{}
+		params.constantTime()
+		b.Run(name+"/fast", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+					b.Fatal("invalid proof")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkGenerateYValues compares the constant-time fixed-base exponentiations of
+// `g` and `h` against plain exponentiations, for each parameter size
+func BenchmarkGenerateYValues(b *testing.B) {
+	for _, set := range benchmarkParams(b) {
+		name, params := set.name, set.params
+		x, err := rand.Int(rand.Reader, params.group.Order())
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		grp := params.group
+		b.Run(name+"/plain", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				grp.Exp(params.g, x)
+				grp.Exp(params.h, x)
+			}
+		})
+
+		params.constantTime()
+		b.Run(name+"/constant-time", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				params.secretExpG(x)
+				params.secretExpH(x)
+			}
+		})
+	}
+}
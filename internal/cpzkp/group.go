@@ -0,0 +1,77 @@
+package cp_zkp
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Names of the groups the Chaum-Pedersen protocol can be run over
+const (
+	GroupModP         = "modp"
+	GroupP256         = "p256"
+	GroupRistretto255 = "ristretto255"
+)
+
+// Element represents a member of the prime order group the protocol runs over.
+type Element interface {
+	// Bytes returns the canonical binary encoding of the element
+	Bytes() []byte
+
+	// String returns the encoding used to exchange the element over the wire
+	String() string
+}
+
+// Group abstracts a cyclic group of prime order `q` in which the discrete log is hard.
+// `Prover` and `Verifier` only use the operations below, so adding a new group does
+// not require any change to the protocol logic or to the server.
+//
+// Scalars (`x`, `k`, `c` and `s`) are represented as *big.Int values in Z_q.
+// The group operation is written multiplicatively: `Mul` and `Exp` correspond
+// to point addition and scalar multiplication on elliptic curves.
+type Group interface {
+	// Name returns the identifier of the group, e.g. `modp` or `p256`
+	Name() string
+
+	// Order returns the prime order `q` of the group
+	Order() *big.Int
+
+	// Identity returns the neutral element of the group
+	Identity() Element
+
+	// Generator returns the standard generator of the group
+	Generator() Element
+
+	// HashToElement deterministically maps the seed to an element whose
+	// discrete log with respect to the generator is unknown
+	HashToElement(seed []byte) Element
+
+	// Mul returns a . b
+	Mul(a, b Element) Element
+
+	// Exp returns base ^ k
+	Exp(base Element, k *big.Int) Element
+
+	// Equal reports whether a and b are the same element
+	Equal(a, b Element) bool
+
+	// Decode parses the canonical binary encoding returned by `Element.Bytes`
+	Decode(b []byte) (Element, error)
+
+	// ParseElement parses the wire encoding returned by `Element.String`
+	ParseElement(str string) (Element, error)
+}
+
+// NewGroup returns the implementation of the named group.
+// The `modp` group uses the sample parameters from the config file.
+func NewGroup(name string) (Group, error) {
+	switch name {
+	case GroupModP:
+		return NewModPGroupFromConfig()
+	case GroupP256:
+		return p256Group{}, nil
+	case GroupRistretto255:
+		return ristretto255Group{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported group %q", name)
+	}
+}
@@ -0,0 +1,225 @@
+package cp_zkp
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/gtank/ristretto255"
+)
+
+// p256Point is an affine point on the NIST P-256 curve
+type p256Point struct {
+	x, y *big.Int
+}
+
+// Bytes returns the compressed SEC 1 encoding of the point, or a single
+// zero byte for the point at infinity
+func (pt p256Point) Bytes() []byte {
+	if pt.x.Sign() == 0 && pt.y.Sign() == 0 {
+		return []byte{0}
+	}
+	return elliptic.MarshalCompressed(elliptic.P256(), pt.x, pt.y)
+}
+
+func (pt p256Point) String() string {
+	return hex.EncodeToString(pt.Bytes())
+}
+
+// p256Group implements `Group` over the NIST P-256 curve using `crypto/elliptic`.
+// The point at infinity is represented by the coordinates (0, 0).
+type p256Group struct{}
+
+func (p256Group) Name() string {
+	return GroupP256
+}
+
+func (p256Group) Order() *big.Int {
+	return new(big.Int).Set(elliptic.P256().Params().N)
+}
+
+func (p256Group) Identity() Element {
+	return p256Point{x: new(big.Int), y: new(big.Int)}
+}
+
+func (p256Group) Generator() Element {
+	params := elliptic.P256().Params()
+	return p256Point{x: new(big.Int).Set(params.Gx), y: new(big.Int).Set(params.Gy)}
+}
+
+// HashToElement maps the seed to a curve point using try-and-increment:
+// x = SHA-256(seed || ctr) is accepted as soon as x^3 - 3x + b is a square mod p.
+func (p256Group) HashToElement(seed []byte) Element {
+	params := elliptic.P256().Params()
+	three := big.NewInt(3)
+
+	var ctr [4]byte
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(ctr[:], i)
+		digest := sha256.Sum256(append(append([]byte{}, seed...), ctr[:]...))
+		x := new(big.Int).SetBytes(digest[:])
+		if x.Cmp(params.P) >= 0 {
+			continue
+		}
+
+		// y^2 = x^3 - 3x + b
+		rhs := new(big.Int).Exp(x, three, params.P)
+		rhs.Sub(rhs, new(big.Int).Mul(three, x))
+		rhs.Add(rhs, params.B)
+		rhs.Mod(rhs, params.P)
+
+		y := new(big.Int).ModSqrt(rhs, params.P)
+		if y == nil {
+			continue
+		}
+
+		// Always pick the even root so the derivation is unambiguous
+		if y.Bit(0) == 1 {
+			y.Sub(params.P, y)
+		}
+		return p256Point{x: x, y: y}
+	}
+}
+
+func (p256Group) Exp(base Element, k *big.Int) Element {
+	pt := base.(p256Point)
+	x, y := elliptic.P256().ScalarMult(pt.x, pt.y, new(big.Int).Mod(k, elliptic.P256().Params().N).Bytes())
+	return p256Point{x: x, y: y}
+}
+
+// expGenerator computes k*G with the precomputed tables of `crypto/elliptic` for the base point
+func (p256Group) expGenerator(k *big.Int) Element {
+	x, y := elliptic.P256().ScalarBaseMult(new(big.Int).Mod(k, elliptic.P256().Params().N).Bytes())
+	return p256Point{x: x, y: y}
+}
+
+func (p256Group) Mul(a, b Element) Element {
+	pa, pb := a.(p256Point), b.(p256Point)
+	x, y := elliptic.P256().Add(pa.x, pa.y, pb.x, pb.y)
+	return p256Point{x: x, y: y}
+}
+
+func (p256Group) Equal(a, b Element) bool {
+	pa, pb := a.(p256Point), b.(p256Point)
+	return pa.x.Cmp(pb.x) == 0 && pa.y.Cmp(pb.y) == 0
+}
+
+func (grp p256Group) Decode(b []byte) (Element, error) {
+	if len(b) == 1 && b[0] == 0 {
+		return grp.Identity(), nil
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), b)
+	if x == nil {
+		return nil, fmt.Errorf("invalid compressed P-256 point")
+	}
+	return p256Point{x: x, y: y}, nil
+}
+
+func (grp p256Group) ParseElement(str string) (Element, error) {
+	return parseHexElement(grp, str)
+}
+
+// ristrettoPoint wraps an element of the prime order ristretto255 group
+type ristrettoPoint struct {
+	e *ristretto255.Element
+}
+
+func (pt ristrettoPoint) Bytes() []byte {
+	return pt.e.Encode(nil)
+}
+
+func (pt ristrettoPoint) String() string {
+	return hex.EncodeToString(pt.Bytes())
+}
+
+// ristretto255Group implements `Group` over the prime order ristretto255 group using `gtank/ristretto255`
+type ristretto255Group struct{}
+
+// ristretto255Order is the prime order of the ristretto255 group: 2^252 + 27742317777372353535851937790883648493
+var ristretto255Order, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+func (ristretto255Group) Name() string {
+	return GroupRistretto255
+}
+
+func (ristretto255Group) Order() *big.Int {
+	return new(big.Int).Set(ristretto255Order)
+}
+
+func (ristretto255Group) Identity() Element {
+	return ristrettoPoint{e: ristretto255.NewElement().Zero()}
+}
+
+func (ristretto255Group) Generator() Element {
+	return ristrettoPoint{e: ristretto255.NewElement().Base()}
+}
+
+// HashToElement maps the seed to a group element with the one-way map from 64 uniform bytes
+func (ristretto255Group) HashToElement(seed []byte) Element {
+	digest := sha512.Sum512(seed)
+	return ristrettoPoint{e: ristretto255.NewElement().FromUniformBytes(digest[:])}
+}
+
+func (ristretto255Group) Exp(base Element, k *big.Int) Element {
+	return ristrettoPoint{e: ristretto255.NewElement().ScalarMult(ristrettoScalar(k), base.(ristrettoPoint).e)}
+}
+
+// expGenerator computes k*B with the precomputed tables of the base point
+func (ristretto255Group) expGenerator(k *big.Int) Element {
+	return ristrettoPoint{e: ristretto255.NewElement().ScalarBaseMult(ristrettoScalar(k))}
+}
+
+func (ristretto255Group) Mul(a, b Element) Element {
+	return ristrettoPoint{e: ristretto255.NewElement().Add(a.(ristrettoPoint).e, b.(ristrettoPoint).e)}
+}
+
+func (ristretto255Group) Equal(a, b Element) bool {
+	return a.(ristrettoPoint).e.Equal(b.(ristrettoPoint).e) == 1
+}
+
+func (ristretto255Group) Decode(b []byte) (Element, error) {
+	e := ristretto255.NewElement()
+	if err := e.Decode(b); err != nil {
+		return nil, err
+	}
+	return ristrettoPoint{e: e}, nil
+}
+
+func (grp ristretto255Group) ParseElement(str string) (Element, error) {
+	return parseHexElement(grp, str)
+}
+
+// ristrettoScalar converts k to the 32-byte little-endian scalar encoding after reducing it mod the group order
+func ristrettoScalar(k *big.Int) *ristretto255.Scalar {
+	be := new(big.Int).Mod(k, ristretto255Order).FillBytes(make([]byte, 32))
+	le := make([]byte, 32)
+	for i := range be {
+		le[i] = be[31-i]
+	}
+
+	s := ristretto255.NewScalar()
+	if err := s.Decode(le); err != nil {
+		// unreachable: the value is fully reduced
+		panic(err)
+	}
+	return s
+}
+
+// parseHexElement decodes the hex encoded wire form of a curve point
+func parseHexElement(grp Group, str string) (Element, error) {
+	b, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hex encoding", ErrNonCanonical)
+	}
+
+	e, err := grp.Decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNonCanonical, err)
+	}
+	return e, nil
+}
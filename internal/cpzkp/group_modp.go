@@ -0,0 +1,145 @@
+package cp_zkp
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/srinathLN7/zkp_auth/lib/config"
+	"github.com/srinathLN7/zkp_auth/lib/util"
+)
+
+// ModPGroup is the order `q` subgroup of the multiplicative group of integers mod `p`
+// generated by `g`. Elements are exchanged over the wire as base-10 strings.
+type ModPGroup struct {
+	p, q, g *big.Int
+}
+
+// modPElement is an integer in [1, p) belonging to the subgroup
+type modPElement struct {
+	v    *big.Int
+	size int // byte length of `p`
+}
+
+func (e modPElement) Bytes() []byte {
+	return e.v.FillBytes(make([]byte, e.size))
+}
+
+func (e modPElement) String() string {
+	return e.v.String()
+}
+
+// NewModPGroup creates the order `q` subgroup of Z_p^* generated by `g`
+func NewModPGroup(p, q, g *big.Int) *ModPGroup {
+	return &ModPGroup{
+		p: new(big.Int).Set(p),
+		q: new(big.Int).Set(q),
+		g: new(big.Int).Set(g),
+	}
+}
+
+// NewModPGroupFromConfig creates the group from the sample `p`, `q` and `g` in the config file
+func NewModPGroupFromConfig() (*ModPGroup, error) {
+	p, err := util.ParseBigInt(config.CPZKP_PARAM_P, "p")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := util.ParseBigInt(config.CPZKP_PARAM_Q, "q")
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := util.ParseBigInt(config.CPZKP_PARAM_G, "g")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewModPGroup(p, q, g), nil
+}
+
+// P returns the modulus `p` of the group
+func (grp *ModPGroup) P() *big.Int {
+	return new(big.Int).Set(grp.p)
+}
+
+// NewElement wraps v as a group element. The caller is responsible for v being in the subgroup
+func (grp *ModPGroup) NewElement(v *big.Int) Element {
+	return grp.element(new(big.Int).Mod(v, grp.p))
+}
+
+func (grp *ModPGroup) element(v *big.Int) modPElement {
+	return modPElement{v: v, size: (grp.p.BitLen() + 7) / 8}
+}
+
+func (grp *ModPGroup) Name() string {
+	return GroupModP
+}
+
+func (grp *ModPGroup) Order() *big.Int {
+	return new(big.Int).Set(grp.q)
+}
+
+func (grp *ModPGroup) Identity() Element {
+	return grp.element(big.NewInt(1))
+}
+
+func (grp *ModPGroup) Generator() Element {
+	return grp.element(new(big.Int).Set(grp.g))
+}
+
+// HashToElement maps the seed into Z_p^* and raises the result to the
+// cofactor (p-1)/q, which lands in the order `q` subgroup
+func (grp *ModPGroup) HashToElement(seed []byte) Element {
+	return grp.element(hashToSubgroup(grp.p, grp.q, seed))
+}
+
+func (grp *ModPGroup) Mul(a, b Element) Element {
+	v := new(big.Int).Mul(a.(modPElement).v, b.(modPElement).v)
+	return grp.element(v.Mod(v, grp.p))
+}
+
+func (grp *ModPGroup) Exp(base Element, k *big.Int) Element {
+	e := new(big.Int).Mod(k, grp.q)
+	return grp.element(new(big.Int).Exp(base.(modPElement).v, e, grp.p))
+}
+
+func (grp *ModPGroup) Equal(a, b Element) bool {
+	return a.(modPElement).v.Cmp(b.(modPElement).v) == 0
+}
+
+func (grp *ModPGroup) Decode(b []byte) (Element, error) {
+	if len(b) != (grp.p.BitLen()+7)/8 {
+		return nil, fmt.Errorf("invalid element length %d", len(b))
+	}
+	return grp.checkRange(new(big.Int).SetBytes(b))
+}
+
+func (grp *ModPGroup) ParseElement(str string) (Element, error) {
+	v, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid base-10 integer", ErrNonCanonical)
+	}
+	return grp.checkRange(v)
+}
+
+// contains reports whether v lies in the order `q` subgroup. For a safe prime `p = 2q+1`
+// the subgroup is the set of quadratic residues, which is checked with the cheap Jacobi
+// symbol; otherwise v^q = 1 is checked with a full exponentiation.
+func (grp *ModPGroup) contains(v *big.Int) bool {
+	if v.Sign() <= 0 || v.Cmp(grp.p) >= 0 {
+		return false
+	}
+
+	safe := new(big.Int).Lsh(grp.q, 1)
+	if safe.Add(safe, big.NewInt(1)).Cmp(grp.p) == 0 {
+		return big.Jacobi(v, grp.p) == 1
+	}
+	return new(big.Int).Exp(v, grp.q, grp.p).Cmp(big.NewInt(1)) == 0
+}
+
+func (grp *ModPGroup) checkRange(v *big.Int) (Element, error) {
+	if v.Sign() <= 0 || v.Cmp(grp.p) >= 0 {
+		return nil, fmt.Errorf("%w: element is not in [1, p-1]", ErrOutOfRange)
+	}
+	return grp.element(v), nil
+}
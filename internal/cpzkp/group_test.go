@@ -0,0 +1,65 @@
+package cp_zkp
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// TestGroupLaws checks that every `Group` implementation behaves like a cyclic group
+// of prime order `q` and that its encodings round trip. New groups should be added here.
+func TestGroupLaws(t *testing.T) {
+
+	for _, name := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(name, func(t *testing.T) {
+
+			grp, err := NewGroup(name)
+			if err != nil {
+				t.Fatalf("error creating group: %v", err)
+			}
+
+			g := grp.Generator()
+			a, b := big.NewInt(123456789), big.NewInt(987654321)
+
+			// g^a . g^b = g^(a+b)
+			lhs := grp.Mul(grp.Exp(g, a), grp.Exp(g, b))
+			rhs := grp.Exp(g, new(big.Int).Add(a, b))
+			if !grp.Equal(lhs, rhs) {
+				t.Errorf("g^a . g^b != g^(a+b)")
+			}
+
+			// g^q = 1 and g . 1 = g
+			if !grp.Equal(grp.Exp(g, grp.Order()), grp.Identity()) {
+				t.Errorf("g^q is not the identity")
+			}
+			if !grp.Equal(grp.Mul(g, grp.Identity()), g) {
+				t.Errorf("g . 1 != g")
+			}
+
+			// h is deterministic and distinct from g
+			h := grp.HashToElement([]byte("test seed"))
+			if !grp.Equal(h, grp.HashToElement([]byte("test seed"))) {
+				t.Errorf("HashToElement is not deterministic")
+			}
+			if grp.Equal(h, g) || grp.Equal(h, grp.Identity()) {
+				t.Errorf("HashToElement returned a trivial element")
+			}
+			if !grp.Equal(grp.Exp(h, grp.Order()), grp.Identity()) {
+				t.Errorf("h is not in the order q subgroup")
+			}
+
+			// Binary and wire encodings round trip
+			for _, e := range []Element{g, h, lhs} {
+				dec, err := grp.Decode(e.Bytes())
+				if err != nil || !grp.Equal(dec, e) || !bytes.Equal(dec.Bytes(), e.Bytes()) {
+					t.Errorf("binary encoding does not round trip: %v", err)
+				}
+
+				parsed, err := grp.ParseElement(e.String())
+				if err != nil || !grp.Equal(parsed, e) {
+					t.Errorf("wire encoding does not round trip: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package cp_zkp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Reasons for rejecting a value received over the wire
+var (
+	ErrNonCanonical  = errors.New("non-canonical encoding")
+	ErrOutOfRange    = errors.New("value out of range")
+	ErrNotInSubgroup = errors.New("element is not in the order q subgroup")
+	ErrIdentity      = errors.New("identity element")
+)
+
+// ErrInvalidInput reports which field of a request failed validation and why.
+// `Reason` wraps one of the errors above and can be tested with `errors.Is`.
+type ErrInvalidInput struct {
+	Field  string
+	Reason error
+}
+
+func (e ErrInvalidInput) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Field, e.Reason)
+}
+
+func (e ErrInvalidInput) Unwrap() error {
+	return e.Reason
+}
+
+// ParseElement parses and validates a group element received over the wire. It only accepts
+// the canonical encoding (`String()` of the decoded element), values in [1, p-1] for the mod-p
+// groups, members of the order `q` subgroup, and never the identity. Failures are reported
+// as `ErrInvalidInput` for `field`.
+func (params *CPZKPParams) ParseElement(str, field string) (Element, error) {
+	e, err := params.group.ParseElement(str)
+	if err != nil {
+		return nil, ErrInvalidInput{Field: field, Reason: err}
+	}
+
+	if e.String() != str {
+		return nil, ErrInvalidInput{Field: field, Reason: ErrNonCanonical}
+	}
+
+	return params.checkElement(e, field)
+}
+
+// checkElement rejects decoded elements outside the order `q` subgroup and the identity
+func (params *CPZKPParams) checkElement(e Element, field string) (Element, error) {
+
+	// Elements outside the subgroup could leak `x` mod a small factor of p-1 (small-subgroup attack)
+	if !inSubgroup(params.group, e) {
+		return nil, ErrInvalidInput{Field: field, Reason: ErrNotInSubgroup}
+	}
+
+	if params.group.Equal(e, params.group.Identity()) {
+		return nil, ErrInvalidInput{Field: field, Reason: ErrIdentity}
+	}
+	return e, nil
+}
+
+// ParseScalar parses and validates a scalar received over the wire: a canonical base-10
+// integer in Z_q, i.e. in [0, q-1]. Failures are reported as `ErrInvalidInput` for `field`.
+func (params *CPZKPParams) ParseScalar(str, field string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(str, 10)
+	if !ok || v.String() != str {
+		return nil, ErrInvalidInput{Field: field, Reason: ErrNonCanonical}
+	}
+
+	if v.Sign() < 0 || v.Cmp(params.group.Order()) >= 0 {
+		return nil, ErrInvalidInput{Field: field, Reason: fmt.Errorf("%w: scalar is not in [0, q-1]", ErrOutOfRange)}
+	}
+	return v, nil
+}
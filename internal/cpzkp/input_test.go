@@ -0,0 +1,125 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// TestParseElement checks that only canonical, non-identity members of the
+// order `q` subgroup are accepted
+func TestParseElement(t *testing.T) {
+	cpZKP, err := NewCPZKP()
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	p := params.Group().(*ModPGroup).P()
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+	y := params.G().String()
+
+	tests := []struct {
+		name string
+		str  string
+		err  error
+	}{
+		{"valid", y, nil},
+		{"not a number", "abc", ErrNonCanonical},
+		{"leading zero", "0" + y, ErrNonCanonical},
+		{"plus sign", "+" + y, ErrNonCanonical},
+		{"zero", "0", ErrOutOfRange},
+		{"negative", "-4", ErrOutOfRange},
+		{"p", p.String(), ErrOutOfRange},
+		{"p+4", new(big.Int).Add(p, big.NewInt(4)).String(), ErrOutOfRange},
+		{"order 2", pMinusOne.String(), ErrNotInSubgroup},
+		{"identity", "1", ErrIdentity},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := params.ParseElement(tc.str, "y1")
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+
+			var input ErrInvalidInput
+			if err != nil && (!errors.As(err, &input) || input.Field != "y1") {
+				t.Errorf("expected the error to name the field, got %v", err)
+			}
+		})
+	}
+}
+
+// TestParseElementCurves checks the canonical encoding and identity checks on the curves
+func TestParseElementCurves(t *testing.T) {
+	for _, group := range []string{GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			g := params.G().String()
+			if _, err := params.ParseElement(g, "r1"); err != nil {
+				t.Fatalf("expected the generator to be accepted: %v", err)
+			}
+
+			invalid := map[string]error{
+				strings.ToUpper(g):                 ErrNonCanonical,
+				"zz":                               ErrNonCanonical,
+				"02" + strings.Repeat("ff", 32):    ErrNonCanonical,
+				params.Group().Identity().String(): ErrIdentity,
+			}
+
+			for str, expected := range invalid {
+				if _, err := params.ParseElement(str, "r1"); !errors.Is(err, expected) {
+					t.Errorf("%q: expected %v, got %v", str, expected, err)
+				}
+			}
+		})
+	}
+}
+
+// TestParseScalar checks that scalars must be canonical integers in Z_q
+func TestParseScalar(t *testing.T) {
+	cpZKP, err := NewCPZKP()
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	q := params.Group().Order()
+	tests := []struct {
+		str string
+		err error
+	}{
+		{"0", nil},
+		{new(big.Int).Sub(q, big.NewInt(1)).String(), nil},
+		{q.String(), ErrOutOfRange},
+		{"-1", ErrOutOfRange},
+		{"012", ErrNonCanonical},
+		{"1e5", ErrNonCanonical},
+		{"", ErrNonCanonical},
+	}
+
+	for _, tc := range tests {
+		if _, err := params.ParseScalar(tc.str, "s"); !errors.Is(err, tc.err) {
+			t.Errorf("%q: expected %v, got %v", tc.str, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Supported password-to-secret derivation functions
+const (
+	KDFArgon2id = "argon2id"
+	KDFScrypt   = "scrypt"
+)
+
+// Bounds on the KDF parameters accepted from a registration. The upper bounds keep a
+// malicious server from making clients spend unbounded memory or time at login.
+const (
+	MinSaltSize = 16
+	MaxSaltSize = 64
+
+	MinKDFMemory = 8 * 1024        // KiB
+	MaxKDFMemory = 4 * 1024 * 1024 // KiB
+	MaxKDFTime   = 64
+	MaxKDFLanes  = 64
+
+	// scrypt block size `r`: with r = 8 every unit of the cost N uses 1 KiB of memory
+	scryptBlockSize = 8
+)
+
+// Errors reported by `KDFParams.Validate`
+var (
+	ErrKDFUnsupported = errors.New("unsupported key derivation function")
+	ErrKDFSalt        = errors.New("invalid salt size")
+	ErrKDFCost        = errors.New("cost parameters out of range")
+)
+
+// KDFParams describes how a password is stretched into the secret `x`. They are chosen
+// by the client at registration, stored by the server next to `y1` and `y2`, and returned
+// to the client at login, so the same password gives a different `x` for every user.
+//
+// For Argon2id, Time is the number of passes, Memory the memory in KiB and Parallelism
+// the number of lanes. For scrypt, Memory is the cost N (a power of two, 1 KiB each with
+// the block size r = 8), Parallelism is p and Time must be 1.
+type KDFParams struct {
+	Algorithm   string
+	Salt        []byte
+	Time        uint32
+	Memory      uint32
+	Parallelism uint32
+}
+
+// NewKDFParams returns Argon2id parameters with a fresh random salt and the cost
+// recommended by RFC 9106 for memory-constrained environments (3 passes, 64 MiB, 4 lanes)
+func NewKDFParams() (*KDFParams, error) {
+	salt := make([]byte, MinSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	return &KDFParams{
+		Algorithm:   KDFArgon2id,
+		Salt:        salt,
+		Time:        3,
+		Memory:      64 * 1024,
+		Parallelism: 4,
+	}, nil
+}
+
+// Validate checks the algorithm, the salt and the cost parameters
+func (kdf *KDFParams) Validate() error {
+	if len(kdf.Salt) < MinSaltSize || len(kdf.Salt) > MaxSaltSize {
+		return fmt.Errorf("%w: %d bytes", ErrKDFSalt, len(kdf.Salt))
+	}
+
+	if kdf.Memory < MinKDFMemory || kdf.Memory > MaxKDFMemory || kdf.Parallelism == 0 || kdf.Parallelism > MaxKDFLanes {
+		return fmt.Errorf("%w: memory %d KiB, parallelism %d", ErrKDFCost, kdf.Memory, kdf.Parallelism)
+	}
+
+	switch kdf.Algorithm {
+	case KDFArgon2id:
+		if kdf.Time == 0 || kdf.Time > MaxKDFTime {
+			return fmt.Errorf("%w: time %d", ErrKDFCost, kdf.Time)
+		}
+	case KDFScrypt:
+		if kdf.Time != 1 || kdf.Memory&(kdf.Memory-1) != 0 {
+			return fmt.Errorf("%w: scrypt needs time 1 and a power of two memory", ErrKDFCost)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrKDFUnsupported, kdf.Algorithm)
+	}
+	return nil
+}
+
+// DeriveSecret stretches the password into the secret x in [1, q). The KDF output is
+// 128 bits longer than `q`, so reducing it mod q leaves a negligible bias.
+func DeriveSecret(password string, kdf *KDFParams, params *CPZKPParams) (*big.Int, error) {
+	if err := kdf.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := params.group.Order()
+	size := (q.BitLen()+7)/8 + 16
+
+	var out []byte
+	switch kdf.Algorithm {
+	case KDFArgon2id:
+		out = argon2.IDKey([]byte(password), kdf.Salt, kdf.Time, kdf.Memory, uint8(kdf.Parallelism), uint32(size))
+	case KDFScrypt:
+		var err error
+		out, err = scrypt.Key([]byte(password), kdf.Salt, int(kdf.Memory), scryptBlockSize, int(kdf.Parallelism), size)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The reduction runs on the constant-time backend, the secret never goes through `math/big` arithmetic
+	x := params.constantTime().scalar(new(big.Int).SetBytes(out)).toBig()
+	if x.Sign() == 0 {
+		// RARE occurence: probability about 2^-|q|
+		return nil, errors.New("degenerate zero secret derived from password")
+	}
+	return x, nil
+}
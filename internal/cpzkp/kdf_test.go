@@ -0,0 +1,98 @@
+package cp_zkp
+
+import (
+	"errors"
+	"testing"
+)
+
+// testKDFParams returns cheap but valid KDF params for tests
+func testKDFParams(algorithm string, salt byte) *KDFParams {
+	kdf := &KDFParams{
+		Algorithm:   algorithm,
+		Salt:        make([]byte, MinSaltSize),
+		Time:        1,
+		Memory:      MinKDFMemory,
+		Parallelism: 1,
+	}
+	kdf.Salt[0] = salt
+	return kdf
+}
+
+// TestDeriveSecret checks that the derived secret is deterministic, in [1, q),
+// and different for another salt or another password
+func TestDeriveSecret(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupRistretto255} {
+		for _, algorithm := range []string{KDFArgon2id, KDFScrypt} {
+			t.Run(group+"/"+algorithm, func(t *testing.T) {
+				cpZKP, err := NewCPZKPWithGroup(group)
+				if err != nil {
+					t.Fatalf("error creating CPZKP instance: %v", err)
+				}
+
+				params, err := cpZKP.InitCPZKPParams()
+				if err != nil {
+					t.Fatalf("error generating ZKP parameters: %v", err)
+				}
+
+				derive := func(password string, kdf *KDFParams) string {
+					x, err := DeriveSecret(password, kdf, params)
+					if err != nil {
+						t.Fatalf("error deriving secret: %v", err)
+					}
+					if x.Sign() <= 0 || x.Cmp(params.Group().Order()) >= 0 {
+						t.Fatalf("secret out of range: %v", x)
+					}
+					return x.String()
+				}
+
+				x := derive("correct horse", testKDFParams(algorithm, 1))
+				if derive("correct horse", testKDFParams(algorithm, 1)) != x {
+					t.Errorf("expected the same secret for the same password and salt")
+				}
+				if derive("correct horse", testKDFParams(algorithm, 2)) == x {
+					t.Errorf("expected a different secret for another salt")
+				}
+				if derive("correct horsf", testKDFParams(algorithm, 1)) == x {
+					t.Errorf("expected a different secret for another password")
+				}
+			})
+		}
+	}
+}
+
+// TestKDFParamsValidate checks the bounds enforced on KDF params
+func TestKDFParamsValidate(t *testing.T) {
+	kdf, err := NewKDFParams()
+	if err != nil {
+		t.Fatalf("error creating KDF params: %v", err)
+	}
+	if err := kdf.Validate(); err != nil {
+		t.Fatalf("expected the default KDF params to be valid: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*KDFParams)
+		err    error
+	}{
+		{"unknown algorithm", func(k *KDFParams) { k.Algorithm = "md5" }, ErrKDFUnsupported},
+		{"short salt", func(k *KDFParams) { k.Salt = k.Salt[:8] }, ErrKDFSalt},
+		{"long salt", func(k *KDFParams) { k.Salt = make([]byte, MaxSaltSize+1) }, ErrKDFSalt},
+		{"low memory", func(k *KDFParams) { k.Memory = 1024 }, ErrKDFCost},
+		{"huge memory", func(k *KDFParams) { k.Memory = MaxKDFMemory + 1 }, ErrKDFCost},
+		{"zero time", func(k *KDFParams) { k.Time = 0 }, ErrKDFCost},
+		{"zero parallelism", func(k *KDFParams) { k.Parallelism = 0 }, ErrKDFCost},
+		{"scrypt time", func(k *KDFParams) { k.Algorithm = KDFScrypt; k.Time = 3 }, ErrKDFCost},
+		{"scrypt memory", func(k *KDFParams) { k.Algorithm = KDFScrypt; k.Time = 1; k.Memory = 3 * 8192 }, ErrKDFCost},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kdf := testKDFParams(KDFArgon2id, 0)
+			tc.modify(kdf)
+			if err := kdf.Validate(); !errors.Is(err, tc.err) {
+				t.Errorf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// nat is a fixed-width natural number stored as little-endian 64-bit limbs. All the
+// nats of one modulus have the same number of limbs as the modulus.
+type nat []uint64
+
+// montModulus implements constant-time arithmetic mod an odd modulus `m` on numbers in
+// Montgomery form a*R mod m, with R = 2^(64*limbs). Unlike `math/big`, the sequence of
+// instructions and memory accesses depends only on the size of the modulus and never on
+// the values, so it is used for every operation on the secret `x` and the nonce `k`.
+type montModulus struct {
+	m     nat
+	m0inv uint64 // -m^-1 mod 2^64
+	rr    nat    // R^2 mod m
+	one   nat    // R mod m, i.e. 1 in Montgomery form
+}
+
+// newMontModulus prepares the odd, public modulus `m`
+func newMontModulus(m *big.Int) *montModulus {
+	n := (m.BitLen() + 63) / 64
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*n))
+
+	mm := &montModulus{
+		m:   natFromBig(m, n),
+		one: natFromBig(new(big.Int).Mod(r, m), n),
+		rr:  natFromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), m), n),
+	}
+
+	// Newton iteration for m0^-1 mod 2^64: each step doubles the number of correct bits
+	inv := mm.m[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - mm.m[0]*inv
+	}
+	mm.m0inv = -inv
+	return mm
+}
+
+// natFromBig returns x as n limbs. Its running time depends only on n and on the number of
+// limbs of x, never on their values.
+func natFromBig(x *big.Int, n int) nat {
+	buf := x.FillBytes(make([]byte, 8*n))
+	z := make(nat, n)
+	for i := range z {
+		for j := 0; j < 8; j++ {
+			z[i] |= uint64(buf[len(buf)-1-8*i-j]) << (8 * j)
+		}
+	}
+	return z
+}
+
+// toBig converts a canonical (non Montgomery) nat back to `math/big` once it is public
+func (x nat) toBig() *big.Int {
+	return new(big.Int).SetBytes(x.bytes())
+}
+
+// bytes returns the fixed-width big-endian encoding of x, 8 bytes per limb
+func (x nat) bytes() []byte {
+	buf := make([]byte, 8*len(x))
+	for i, limb := range x {
+		for j := 0; j < 8; j++ {
+			buf[len(buf)-1-8*i-j] = byte(limb >> (8 * j))
+		}
+	}
+	return buf
+}
+
+// fromBig returns the Montgomery form of x mod m for any non-negative x. Wide values,
+// e.g. a password-derived secret larger than `q`, are reduced limb block by limb block
+// with Horner's rule: x = sum(x_i * R^i).
+func (mm *montModulus) fromBig(x *big.Int) nat {
+	n := len(mm.m)
+	blocks := (x.BitLen() + 64*n - 1) / (64 * n)
+	if blocks == 0 {
+		blocks = 1
+	}
+
+	wide := natFromBig(x, blocks*n)
+	acc := mm.mul(wide[(blocks-1)*n:], mm.rr)
+	for i := blocks - 2; i >= 0; i-- {
+		acc = mm.add(mm.mul(acc, mm.rr), mm.mul(wide[i*n:(i+1)*n], mm.rr))
+	}
+	return acc
+}
+
+// toCanonical converts out of Montgomery form: a*R -> a
+func (mm *montModulus) toCanonical(a nat) nat {
+	one := make(nat, len(mm.m))
+	one[0] = 1
+	return mm.mul(a, one)
+}
+
+// mul returns a*b/R mod m (CIOS Montgomery multiplication). Inputs are < m or, for the
+// conversion into Montgomery form, < R with the other operand < m.
+func (mm *montModulus) mul(a, b nat) nat {
+	n := len(mm.m)
+	t := make(nat, n+2)
+	for i := 0; i < n; i++ {
+		// t += a * b[i]
+		var c uint64
+		for j := 0; j < n; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			lo, c1 := bits.Add64(lo, t[j], 0)
+			lo, c2 := bits.Add64(lo, c, 0)
+			t[j], c = lo, hi+c1+c2
+		}
+		t[n], c = bits.Add64(t[n], c, 0)
+		t[n+1] = c
+
+		// t = (t + u*m) / 2^64, where u makes the lowest limb vanish
+		u := t[0] * mm.m0inv
+		hi, lo := bits.Mul64(u, mm.m[0])
+		_, c1 := bits.Add64(lo, t[0], 0)
+		c = hi + c1
+		for j := 1; j < n; j++ {
+			hi, lo := bits.Mul64(u, mm.m[j])
+			lo, c1 := bits.Add64(lo, t[j], 0)
+			lo, c2 := bits.Add64(lo, c, 0)
+			t[j-1], c = lo, hi+c1+c2
+		}
+		t[n-1], c = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + c
+	}
+	return mm.reduce(t[:n], t[n])
+}
+
+// add returns a+b mod m
+func (mm *montModulus) add(a, b nat) nat {
+	t := make(nat, len(mm.m))
+	var c uint64
+	for i := range t {
+		t[i], c = bits.Add64(a[i], b[i], c)
+	}
+	return mm.reduce(t, c)
+}
+
+// sub returns a-b mod m
+func (mm *montModulus) sub(a, b nat) nat {
+	t := make(nat, len(mm.m))
+	var borrow uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+
+	// add m back if the subtraction wrapped around
+	mask := -borrow
+	var c uint64
+	for i := range t {
+		t[i], c = bits.Add64(t[i], mm.m[i]&mask, c)
+	}
+	return t
+}
+
+// reduce returns t mod m for t = top*2^(64n) + t < 2m, subtracting m without branching
+func (mm *montModulus) reduce(t nat, top uint64) nat {
+	d := make(nat, len(mm.m))
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(t[i], mm.m[i], borrow)
+	}
+	_, borrow = bits.Sub64(top, 0, borrow)
+
+	// borrow == 1 means t < m: keep t
+	ctSelect(d, t, -borrow)
+	return d
+}
+
+// exp returns base^e in Montgomery form for an exponent of `ebits` bits, using fixed
+// 4-bit windows: every window costs the same squarings, one table scan and one
+// multiplication, whatever the digit
+func (mm *montModulus) exp(table []nat, e nat, ebits int) nat {
+	acc := append(nat(nil), mm.one...)
+	for w := (ebits+multiExpWindow-1)/multiExpWindow - 1; w >= 0; w-- {
+		for j := 0; j < multiExpWindow; j++ {
+			acc = mm.mul(acc, acc)
+		}
+		acc = mm.mul(acc, ctLookup(table, natDigit(e, w)))
+	}
+	return acc
+}
+
+// windowTable returns base^0, ..., base^(2^w - 1) in Montgomery form
+func (mm *montModulus) windowTable(base nat) []nat {
+	table := make([]nat, 1<<multiExpWindow)
+	table[0] = append(nat(nil), mm.one...)
+	table[1] = base
+	for d := 2; d < len(table); d++ {
+		table[d] = mm.mul(table[d-1], base)
+	}
+	return table
+}
+
+// natDigit returns the w-th window of `multiExpWindow` bits of e. The windows never
+// straddle two limbs and the shifts only depend on the public position w.
+func natDigit(e nat, w int) uint64 {
+	pos := w * multiExpWindow
+	if pos/64 >= len(e) {
+		return 0
+	}
+	return (e[pos/64] >> (pos % 64)) & (1<<multiExpWindow - 1)
+}
+
+// ctLookup returns table[d], reading every entry so that the access pattern does not depend on d
+func ctLookup(table []nat, d uint64) nat {
+	out := make(nat, len(table[0]))
+	for i, entry := range table {
+		mask := ctEq(uint64(i), d)
+		for j := range out {
+			out[j] |= entry[j] & mask
+		}
+	}
+	return out
+}
+
+// ctSelect sets dst = src where mask is all ones, and leaves dst unchanged where it is zero
+func ctSelect(dst, src nat, mask uint64) {
+	for i := range dst {
+		dst[i] = src[i]&mask | dst[i]&^mask
+	}
+}
+
+// ctEq returns all ones if a == b and zero otherwise
+func ctEq(a, b uint64) uint64 {
+	x := a ^ b
+	return ((x | -x) >> 63) - 1
+}
@@ -0,0 +1,62 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// TestMontModulus checks the constant-time Montgomery arithmetic against `math/big`
+func TestMontModulus(t *testing.T) {
+	ffdhe, err := NewPresetParams(PresetFFDHE2048)
+	if err != nil {
+		t.Fatalf("error loading preset: %v", err)
+	}
+
+	moduli := map[string]*big.Int{
+		"small":        big.NewInt(1000003),
+		"p256":         p256Group{}.Order(),
+		"ristretto255": ristretto255Group{}.Order(),
+		"ffdhe2048":    ffdhe.Group().(*ModPGroup).P(),
+	}
+
+	for name, m := range moduli {
+		t.Run(name, func(t *testing.T) {
+			mm := newMontModulus(m)
+
+			for i := 0; i < 32; i++ {
+				// a is wider than the modulus to exercise the reduction in `fromBig`
+				a, err := rand.Int(rand.Reader, new(big.Int).Lsh(m, 200))
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := rand.Int(rand.Reader, m)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				am, bm := mm.fromBig(a), mm.fromBig(b)
+
+				check := func(op string, got nat, expected *big.Int) {
+					if mm.toCanonical(got).toBig().Cmp(expected.Mod(expected, m)) != 0 {
+						t.Fatalf("%s mismatch for a = %v, b = %v", op, a, b)
+					}
+				}
+
+				check("fromBig", am, new(big.Int).Set(a))
+				check("mul", mm.mul(am, bm), new(big.Int).Mul(a, b))
+				check("add", mm.add(am, bm), new(big.Int).Add(a, b))
+				check("sub", mm.sub(am, bm), new(big.Int).Sub(a, b))
+				check("sub", mm.sub(bm, am), new(big.Int).Sub(b, a))
+
+				e := natFromBig(b, len(mm.m))
+				check("exp", mm.exp(mm.windowTable(am), e, m.BitLen()), new(big.Int).Exp(a, b, m))
+			}
+
+			zero := mm.fromBig(new(big.Int))
+			if mm.toCanonical(zero).toBig().Sign() != 0 {
+				t.Errorf("expected zero to round-trip")
+			}
+		})
+	}
+}
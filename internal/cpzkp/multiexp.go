@@ -0,0 +1,91 @@
+package cp_zkp
+
+import (
+	"math/big"
+
+	"github.com/gtank/ristretto255"
+)
+
+// Window size (in bits) of the interleaved multi-exponentiation
+const multiExpWindow = 4
+
+// multiExper is implemented by groups with a native multi-exponentiation
+type multiExper interface {
+	multiExp(bases []Element, exps []*big.Int) Element
+}
+
+// MultiExp computes the product of bases[i]^exps[i] with a single simultaneous
+// (Straus) multi-exponentiation: the squarings are shared by all the bases, so the
+// cost grows with the number of multiplications rather than with the number of
+// full exponentiations. Exponents are reduced mod the group order first.
+func MultiExp(grp Group, bases []Element, exps []*big.Int) Element {
+	if len(bases) != len(exps) {
+		panic("cp_zkp: MultiExp called with mismatched bases and exponents")
+	}
+
+	if native, ok := grp.(multiExper); ok {
+		return native.multiExp(bases, exps)
+	}
+
+	q := grp.Order()
+	reduced := make([]*big.Int, len(exps))
+	maxBits := 0
+	for i, e := range exps {
+		reduced[i] = new(big.Int).Mod(e, q)
+		if reduced[i].BitLen() > maxBits {
+			maxBits = reduced[i].BitLen()
+		}
+	}
+
+	// tables[i][d] = bases[i]^d for every window digit d
+	tables := make([][]Element, len(bases))
+	for i, base := range bases {
+		tables[i] = windowTable(grp, base)
+	}
+
+	acc := grp.Identity()
+	windows := (maxBits + multiExpWindow - 1) / multiExpWindow
+	for w := windows - 1; w >= 0; w-- {
+		for j := 0; j < multiExpWindow; j++ {
+			acc = grp.Mul(acc, acc)
+		}
+		for i := range bases {
+			if d := windowDigit(reduced[i], w); d != 0 {
+				acc = grp.Mul(acc, tables[i][d])
+			}
+		}
+	}
+	return acc
+}
+
+// windowTable returns base^0, base^1, ..., base^(2^w - 1)
+func windowTable(grp Group, base Element) []Element {
+	table := make([]Element, 1<<multiExpWindow)
+	table[0] = grp.Identity()
+	table[1] = base
+	for d := 2; d < len(table); d++ {
+		table[d] = grp.Mul(table[d-1], base)
+	}
+	return table
+}
+
+// windowDigit returns the w-th window of `multiExpWindow` bits of e
+func windowDigit(e *big.Int, w int) uint {
+	var d uint
+	for j := multiExpWindow - 1; j >= 0; j-- {
+		d = d<<1 | e.Bit(w*multiExpWindow+j)
+	}
+	return d
+}
+
+// multiExp uses the variable-time multi-scalar multiplication of ristretto255,
+// which is only ever applied to public values
+func (ristretto255Group) multiExp(bases []Element, exps []*big.Int) Element {
+	points := make([]*ristretto255.Element, len(bases))
+	scalars := make([]*ristretto255.Scalar, len(exps))
+	for i := range bases {
+		points[i] = bases[i].(ristrettoPoint).e
+		scalars[i] = ristrettoScalar(exps[i])
+	}
+	return ristrettoPoint{e: ristretto255.NewElement().VarTimeMultiScalarMult(scalars, points)}
+}
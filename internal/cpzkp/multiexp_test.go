@@ -0,0 +1,57 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// TestMultiExp checks the native multi-exponentiations of the curves against plain
+// exponentiations, including the identity, opposite points and repeated bases
+func TestMultiExp(t *testing.T) {
+	for _, name := range []string{GroupP256, GroupRistretto255} {
+		t.Run(name, func(t *testing.T) {
+			grp, err := NewGroup(name)
+			if err != nil {
+				t.Fatalf("error creating group: %v", err)
+			}
+
+			q := grp.Order()
+			g := grp.Generator()
+			h := grp.HashToElement([]byte("h"))
+			minusG := grp.Exp(g, new(big.Int).Sub(q, big.NewInt(1)))
+
+			random := func() *big.Int {
+				k, err := rand.Int(rand.Reader, q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return k
+			}
+
+			tests := []struct {
+				bases []Element
+				exps  []*big.Int
+			}{
+				{[]Element{g, h}, []*big.Int{random(), random()}},
+				{[]Element{g, h, minusG}, []*big.Int{random(), random(), random()}},
+				{[]Element{g, h}, []*big.Int{big.NewInt(0), big.NewInt(0)}},
+				{[]Element{g, h}, []*big.Int{big.NewInt(1), q}},
+				{[]Element{g, h}, []*big.Int{new(big.Int).Sub(q, big.NewInt(1)), new(big.Int).Lsh(q, 7)}},
+				{[]Element{g, minusG}, []*big.Int{big.NewInt(5), big.NewInt(5)}},
+				{[]Element{g, g}, []*big.Int{big.NewInt(3), big.NewInt(3)}},
+				{[]Element{grp.Identity(), h}, []*big.Int{random(), random()}},
+			}
+
+			for i, tc := range tests {
+				expected := grp.Identity()
+				for j := range tc.bases {
+					expected = grp.Mul(expected, grp.Exp(tc.bases[j], tc.exps[j]))
+				}
+				if got := MultiExp(grp, tc.bases, tc.exps); !grp.Equal(got, expected) {
+					t.Errorf("case %d: MultiExp does not match Exp", i)
+				}
+			}
+		})
+	}
+}
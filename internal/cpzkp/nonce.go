@@ -0,0 +1,94 @@
+package cp_zkp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+)
+
+const (
+	// Domain separation tag of the context hashed into hedged nonces
+	hedgedNonceDST = "zkp_auth/cpzkp/hedged-nonce/v1"
+
+	// Size of the fresh randomness mixed into hedged nonces
+	hedgedEntropySize = 32
+)
+
+// ErrNoSecret is returned when a hedged nonce is requested from a prover without a secret
+var ErrNoSecret = errors.New("hedged nonces need the prover's secret `x`")
+
+// hmacDRBG is the HMAC_DRBG of NIST SP 800-90A with SHA-256, as used by RFC 6979 to derive
+// deterministic nonces. It is instantiated once per nonce and never reseeded.
+type hmacDRBG struct {
+	k, v []byte
+}
+
+// newHMACDRBG instantiates the generator from the concatenation of the seed material
+func newHMACDRBG(seed ...[]byte) *hmacDRBG {
+	d := &hmacDRBG{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(seed...)
+	return d
+}
+
+// mac returns HMAC_K(data...)
+func (d *hmacDRBG) mac(data ...[]byte) []byte {
+	h := hmac.New(sha256.New, d.k)
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// update is HMAC_DRBG_Update: it mixes the provided data into K and V
+func (d *hmacDRBG) update(data ...[]byte) {
+	d.k = d.mac(append([][]byte{d.v, {0x00}}, data...)...)
+	d.v = d.mac(d.v)
+	if len(data) == 0 {
+		return
+	}
+	d.k = d.mac(append([][]byte{d.v, {0x01}}, data...)...)
+	d.v = d.mac(d.v)
+}
+
+// Read is HMAC_DRBG_Generate: it fills b with the chain V = HMAC_K(V) and updates the
+// state afterwards, so a candidate rejected by `RandomScalar` is followed by a fresh one
+// exactly as in RFC 6979, section 3.2, step h.
+func (d *hmacDRBG) Read(b []byte) (int, error) {
+	for n := 0; n < len(b); {
+		d.v = d.mac(d.v)
+		n += copy(b[n:], d.v)
+	}
+	d.update()
+	return len(b), nil
+}
+
+// hedgedNonce derives the nonce `k` from the secret `x`, the context of the proof and fresh
+// randomness with HMAC_DRBG (RFC 6979, section 3.6). A predictable or repeating RNG then
+// gives the same `k` only for the same `x` and context, and an attacker who knows the
+// randomness still cannot compute `k` without `x`.
+func (p *Prover) hedgedNonce(params *CPZKPParams, context []byte) (*big.Int, error) {
+	if p.x == nil {
+		return nil, ErrNoSecret
+	}
+
+	entropy := make([]byte, hedgedEntropySize)
+	if _, err := io.ReadFull(randomReader(p.Rand), entropy); err != nil {
+		return nil, err
+	}
+
+	t := NewTranscript(hedgedNonceDST)
+	t.AppendParams(params)
+	t.AppendMessage("context", context)
+
+	// `x` enters the generator as fixed-width bytes reduced on the constant-time backend
+	x := params.constantTime().scalar(p.x).bytes()
+	return RandomScalar(newHMACDRBG(x, entropy, t.hash.Sum(nil)), params.group.Order())
+}
@@ -0,0 +1,105 @@
+package cp_zkp
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// TestHMACDRBG checks the generator and the rejection sampling against the deterministic
+// nonce of RFC 6979, appendix A.2.5 (P-256, SHA-256, message "sample")
+func TestHMACDRBG(t *testing.T) {
+	x, _ := hex.DecodeString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	h1 := sha256.Sum256([]byte("sample"))
+
+	k, err := RandomScalar(newHMACDRBG(x, h1[:]), elliptic.P256().Params().N)
+	if err != nil {
+		t.Fatalf("error deriving nonce: %v", err)
+	}
+
+	expected, _ := new(big.Int).SetString("A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60", 16)
+	if k.Cmp(expected) != 0 {
+		t.Errorf("expected k = %X, got %X", expected, k)
+	}
+}
+
+// TestHedgedNonce checks that hedged nonces stay distinct across secrets and contexts
+// even when the RNG returns nothing but zeros, and that the proofs still verify
+func TestHedgedNonce(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			nonce := func(x int64, context string) *big.Int {
+				prover := NewProver(big.NewInt(x))
+				prover.Rand = zeroReader{}
+				prover.Hedged = true
+
+				k, err := prover.hedgedNonce(params, []byte(context))
+				if err != nil {
+					t.Fatalf("error deriving hedged nonce: %v", err)
+				}
+				return k
+			}
+
+			k := nonce(123456789, "alice")
+			if k.Cmp(nonce(123456789, "alice")) != 0 {
+				t.Errorf("expected the same nonce for the same secret, context and randomness")
+			}
+			if k.Cmp(nonce(987654321, "alice")) == 0 {
+				t.Errorf("expected another nonce for another secret")
+			}
+			if k.Cmp(nonce(123456789, "bob")) == 0 {
+				t.Errorf("expected another nonce for another context")
+			}
+
+			prover := NewProver(big.NewInt(123456789))
+			prover.Rand = zeroReader{}
+			prover.Hedged = true
+
+			y1, y2 := prover.GenerateYValues(params)
+			proof, err := prover.CreateNIProof(params, []byte("alice"))
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+
+			verifier := Verifier{}
+			if !verifier.VerifyNIProof(y1, y2, proof, []byte("alice"), params) {
+				t.Errorf("expected the hedged proof to verify")
+			}
+
+			if _, _, _, err := (&Prover{Hedged: true}).CreateProofCommitment(params); !errors.Is(err, ErrNoSecret) {
+				t.Errorf("expected ErrNoSecret without a secret, got %v", err)
+			}
+
+			// Interactive commitments are hedged with the prover's context: two logins that
+			// differ only in their session value commit with distinct nonces
+			commit := func(session string) *big.Int {
+				prover.Context = LoginContext("server", "alice", LoginFactorPassword, []byte(session))
+				k, _, _, err := prover.CreateProofCommitment(params)
+				if err != nil {
+					t.Fatalf("error creating commitment: %v", err)
+				}
+				return k
+			}
+
+			if commit("login-1").Cmp(commit("login-2")) == 0 {
+				t.Errorf("expected distinct nonces for distinct login sessions")
+			}
+			if commit("login-1").Cmp(commit("login-1")) != 0 {
+				t.Errorf("expected the same nonce for the same login context and randomness")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Errors reported when an OR proof cannot be created
+var (
+	ErrEmptyStatements = errors.New("an OR proof needs at least one statement")
+	ErrNotAWitness     = errors.New("the prover's secret is not a witness for the selected statement")
+)
+
+// Statement is the public values (y1, y2) of one account: the claim log_g(y1) = log_h(y2)
+type Statement struct {
+	Y1, Y2 Element
+}
+
+// Commitment is the first message (r1, r2) of one branch of an OR proof
+type Commitment struct {
+	R1, R2 Element
+}
+
+// ORState is the prover's private state between the commitment and the response of an
+// OR proof. It must not be sent anywhere: it holds the nonce of the real branch and the
+// challenges of the simulated ones.
+type ORState struct {
+	index       int
+	k           *big.Int
+	transcripts []*ProofTranscript
+}
+
+// CreateORCommitment starts a disjunctive (OR) proof that the prover knows the secret of
+// one of the statements, without revealing which. The prover's secret `x` must be the
+// witness of statements[index]. That branch commits honestly with a random `k`; every
+// other branch is answered in advance by the simulator, which picks its challenge and
+// response. The returned commitments are sent to the verifier in the statements' order.
+func (p *Prover) CreateORCommitment(params *CPZKPParams, statements []Statement, index int) (*ORState, []Commitment, error) {
+	if len(statements) == 0 {
+		return nil, nil, ErrEmptyStatements
+	}
+
+	if index < 0 || index >= len(statements) || p.x == nil ||
+		!ctEqual(params.secretExpG(p.x), statements[index].Y1) || !ctEqual(params.secretExpH(p.x), statements[index].Y2) {
+		return nil, nil, ErrNotAWitness
+	}
+
+	k, r1, r2, err := p.commit(params, p.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state := &ORState{index: index, k: k, transcripts: make([]*ProofTranscript, len(statements))}
+	commitments := make([]Commitment, len(statements))
+	for i, st := range statements {
+		if i == index {
+			state.transcripts[i] = &ProofTranscript{R1: r1, R2: r2}
+		} else {
+			state.transcripts[i], err = SimulateTranscript(params, st.Y1, st.Y2, p.Rand)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		commitments[i] = Commitment{R1: state.transcripts[i].R1, R2: state.transcripts[i].R2}
+	}
+	return state, commitments, nil
+}
+
+// CreateORChallengeResponse answers the verifier's challenge `c`. The challenge of the real
+// branch is fixed by c = sum(c_i) mod q, and its response is s = (k - c_i * x) mod q. Every
+// branch is returned as a full transcript; the verifier receives their (c_i, s_i).
+func (p *Prover) CreateORChallengeResponse(state *ORState, c *big.Int, params *CPZKPParams) []*ProofTranscript {
+	q := params.group.Order()
+
+	ci := new(big.Int).Set(c)
+	for i, t := range state.transcripts {
+		if i != state.index {
+			ci.Sub(ci, t.C)
+		}
+	}
+	ci.Mod(ci, q)
+
+	witness := state.transcripts[state.index]
+	witness.C = ci
+	witness.S = p.CreateProofChallengeResponse(state.k, ci, params)
+	return state.transcripts
+}
+
+// VerifyORProof verifies that the prover knows the secret of at least one statement. Every
+// branch must be an accepting transcript for its statement and the branch challenges must
+// add up to the verifier's challenge `c`. Since the prover could only choose all but one
+// of them, one branch was answered with the real witness.
+func (v *Verifier) VerifyORProof(params *CPZKPParams, statements []Statement, transcripts []*ProofTranscript, c *big.Int) bool {
+	if len(statements) == 0 || len(transcripts) != len(statements) {
+		return false
+	}
+
+	q := params.group.Order()
+	sum := new(big.Int)
+	valid := true
+	for i, t := range transcripts {
+		if t == nil || t.R1 == nil || t.R2 == nil || t.C == nil || t.S == nil {
+			return false
+		}
+
+		// Every branch is checked, so the running time does not depend on which one is real
+		valid = v.VerifyProof(statements[i].Y1, statements[i].Y2, t.R1, t.R2, t.C, t.S, params) && valid
+		sum.Add(sum, t.C)
+	}
+
+	return valid && sum.Mod(sum, q).Cmp(new(big.Int).Mod(c, q)) == 0
+}
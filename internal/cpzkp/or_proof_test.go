@@ -0,0 +1,86 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// TestORProof tests that a member proves knowledge of one of the secrets whichever its
+// position is, and that tampered proofs and non-members are rejected
+func TestORProof(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			provers := make([]*Prover, 4)
+			statements := make([]Statement, len(provers))
+			for i := range provers {
+				provers[i] = NewProver(big.NewInt(int64(1000 + i)))
+				statements[i].Y1, statements[i].Y2 = provers[i].GenerateYValues(params)
+			}
+
+			verifier := Verifier{}
+			prove := func(prover *Prover, index int) ([]*ProofTranscript, *big.Int) {
+				state, commitments, err := prover.CreateORCommitment(params, statements, index)
+				if err != nil {
+					t.Fatalf("error creating OR commitment: %v", err)
+				}
+
+				c, err := verifier.CreateProofChallenge(params)
+				if err != nil {
+					t.Fatalf("error creating challenge: %v", err)
+				}
+
+				transcripts := prover.CreateORChallengeResponse(state, c, params)
+				for i, tr := range transcripts {
+					if !params.Group().Equal(tr.R1, commitments[i].R1) || !params.Group().Equal(tr.R2, commitments[i].R2) {
+						t.Fatalf("expected branch %d to answer its commitment", i)
+					}
+				}
+				return transcripts, c
+			}
+
+			for i, prover := range provers {
+				transcripts, c := prove(prover, i)
+				if !verifier.VerifyORProof(params, statements, transcripts, c) {
+					t.Errorf("expected the proof of member %d to verify", i)
+				}
+			}
+
+			transcripts, c := prove(provers[1], 1)
+
+			if verifier.VerifyORProof(params, statements, transcripts, new(big.Int).Add(c, big.NewInt(1))) {
+				t.Errorf("expected a proof for another challenge to fail")
+			}
+
+			if verifier.VerifyORProof(params, statements[:3], transcripts[:3], c) {
+				t.Errorf("expected a proof for another set of statements to fail")
+			}
+
+			transcripts[3].S = new(big.Int).Add(transcripts[3].S, big.NewInt(1))
+			if verifier.VerifyORProof(params, statements, transcripts, c) {
+				t.Errorf("expected a tampered proof to fail")
+			}
+
+			outsider := NewProver(big.NewInt(7))
+			if _, _, err := outsider.CreateORCommitment(params, statements, 0); !errors.Is(err, ErrNotAWitness) {
+				t.Errorf("expected ErrNotAWitness for a non-member, got %v", err)
+			}
+			if _, _, err := provers[0].CreateORCommitment(params, statements, 1); !errors.Is(err, ErrNotAWitness) {
+				t.Errorf("expected ErrNotAWitness for another member's statement, got %v", err)
+			}
+			if _, _, err := provers[0].CreateORCommitment(params, nil, 0); !errors.Is(err, ErrEmptyStatements) {
+				t.Errorf("expected ErrEmptyStatements, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/srinathLN7/zkp_auth/lib/util"
+)
+
+// Domain separation tag mixed into the hash-to-group derivation of `g` and `h`
+const paramGenDST = "zkp_auth/cpzkp/modp/paramgen/v1"
+
+// ParamFile is the serialized form of a generated `modp` parameter set.
+// The seed is published along with the parameters so that anyone can
+// re-derive `g` and `h` with `DeriveGenerators` and check that no trapdoor
+// (a known log_g(h)) was planted. All integers are base-10 strings.
+type ParamFile struct {
+	Group string `json:"group"`
+	Bits  int    `json:"bits"`
+	P     string `json:"p"`
+	Q     string `json:"q"`
+	G     string `json:"g"`
+	H     string `json:"h"`
+	Seed  string `json:"seed"` // hex encoded
+}
+
+// GenerateSafePrime returns a safe prime `p = 2q+1` of exactly `bits` bits together with the prime `q`.
+// Sizes down to the insecure floor are generated for tests, but only `bits >= MinModPBits`
+// yields parameters that `NewModPParams` accepts.
+func GenerateSafePrime(random io.Reader, bits int) (p, q *big.Int, err error) {
+	if bits < InsecureMinModPBits+1 {
+		return nil, nil, fmt.Errorf("bit size %d is smaller than the minimum of %d", bits, InsecureMinModPBits+1)
+	}
+
+	one := big.NewInt(1)
+	buf := make([]byte, (bits-1+7)/8)
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, nil, err
+		}
+
+		// Candidate `q` of exactly bits-1 bits with the two top bits set so that
+		// `p = 2q+1` has exactly `bits` bits, and `q` odd
+		q = new(big.Int).SetBytes(buf)
+		q.SetBit(q, bits-2, 1)
+		q.SetBit(q, bits-3, 1)
+		q.SetBit(q, 0, 1)
+		for i := bits - 1; i < len(buf)*8; i++ {
+			q.SetBit(q, i, 0)
+		}
+
+		if !sieveSafePrimeCandidate(q) {
+			continue
+		}
+
+		if !q.ProbablyPrime(20) {
+			continue
+		}
+
+		p = new(big.Int).Lsh(q, 1)
+		p.Add(p, one)
+		if p.ProbablyPrime(20) {
+			return p, q, nil
+		}
+	}
+}
+
+// smallPrimes is used to discard most candidates before running the expensive primality tests
+var smallPrimes = []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97}
+
+// sieveSafePrimeCandidate reports whether neither `q` nor `2q+1` has a small prime factor
+func sieveSafePrimeCandidate(q *big.Int) bool {
+	for _, sp := range smallPrimes {
+		r := new(big.Int).Mod(q, new(big.Int).SetUint64(sp)).Uint64()
+		// q = 0 mod sp  or  2q+1 = 0 mod sp
+		if r == 0 || (2*r+1)%sp == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// DeriveGenerators derives the two generators `g` and `h` of the order `q` subgroup of Z_p^*
+// from a public seed ("nothing up my sleeve"). Each generator is obtained by hashing the
+// domain tag, its label, `p`, `q` and the seed into Z_p^* and raising the result to (p-1)/q.
+func DeriveGenerators(p, q *big.Int, seed []byte) (g, h *big.Int) {
+	return hashToSubgroup(p, q, generatorSeed("g", p, q, seed)), DeriveGeneratorH(p, q, seed)
+}
+
+// DeriveGeneratorH derives only the second generator `h` from the seed. It is used
+// with groups that come with a standard generator `g`, such as the RFC presets.
+func DeriveGeneratorH(p, q *big.Int, seed []byte) *big.Int {
+	return hashToSubgroup(p, q, generatorSeed("h", p, q, seed))
+}
+
+// generatorSeed builds the unambiguous hash input for the generator with the given label
+func generatorSeed(label string, p, q *big.Int, seed []byte) []byte {
+	var buf []byte
+	for _, field := range [][]byte{[]byte(paramGenDST), []byte(label), p.Bytes(), q.Bytes(), seed} {
+		var l [4]byte
+		binary.BigEndian.PutUint32(l[:], uint32(len(field)))
+		buf = append(buf, l[:]...)
+		buf = append(buf, field...)
+	}
+	return buf
+}
+
+// hashToSubgroup expands SHA-256(seed || ctr || block) to a value v mod p and raises it to the
+// cofactor (p-1)/q, which lands in the order `q` subgroup. Identity results are skipped.
+func hashToSubgroup(p, q *big.Int, seed []byte) *big.Int {
+	cofactor := new(big.Int).Sub(p, big.NewInt(1))
+	cofactor.Div(cofactor, q)
+
+	// 128 extra bits make the reduction mod p statistically uniform
+	size := (p.BitLen()+7)/8 + 16
+
+	var ctr [4]byte
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(ctr[:], i)
+
+		var buf []byte
+		for block := uint32(0); len(buf) < size; block++ {
+			var blk [4]byte
+			binary.BigEndian.PutUint32(blk[:], block)
+			digest := sha256.Sum256(append(append(append([]byte{}, seed...), ctr[:]...), blk[:]...))
+			buf = append(buf, digest[:]...)
+		}
+
+		v := new(big.Int).SetBytes(buf[:size])
+		v.Mod(v, p)
+		v.Exp(v, cofactor, p)
+		if v.Cmp(big.NewInt(1)) > 0 {
+			return v
+		}
+	}
+}
+
+// GenerateParamFile generates a fresh safe prime of `bits` bits and derives `g` and `h` from `seed`.
+// If `seed` is empty, 32 random bytes are used and recorded in the parameter file.
+func GenerateParamFile(bits int, seed []byte) (*ParamFile, error) {
+	if len(seed) == 0 {
+		seed = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+			return nil, err
+		}
+	}
+
+	p, q, err := GenerateSafePrime(rand.Reader, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	g, h := DeriveGenerators(p, q, seed)
+
+	return &ParamFile{
+		Group: GroupModP,
+		Bits:  bits,
+		P:     p.String(),
+		Q:     q.String(),
+		G:     g.String(),
+		H:     h.String(),
+		Seed:  hex.EncodeToString(seed),
+	}, nil
+}
+
+// Verify validates the parameters with `NewModPParams`, checks that `p = 2q+1` is a
+// safe prime, and re-derives `g` and `h` from the published seed to check that they match.
+func (f *ParamFile) Verify() error {
+	return f.verify(false)
+}
+
+// VerifyInsecure is `Verify` with the floors of `NewModPParamsInsecure`. It is meant for tests only.
+func (f *ParamFile) VerifyInsecure() error {
+	return f.verify(true)
+}
+
+func (f *ParamFile) verify(insecure bool) error {
+	if f.Group != GroupModP {
+		return fmt.Errorf("unsupported group %q in parameter file", f.Group)
+	}
+
+	p, q, g, h, err := f.parse()
+	if err != nil {
+		return err
+	}
+
+	seed, err := hex.DecodeString(f.Seed)
+	if err != nil || len(seed) == 0 {
+		return fmt.Errorf("invalid seed in parameter file")
+	}
+
+	if p.BitLen() != f.Bits {
+		return fmt.Errorf("p has %d bits, expected %d", p.BitLen(), f.Bits)
+	}
+
+	if _, err := newModPParams(p, q, g, h, insecure); err != nil {
+		return err
+	}
+
+	if new(big.Int).Add(new(big.Int).Lsh(q, 1), big.NewInt(1)).Cmp(p) != 0 {
+		return fmt.Errorf("p is not equal to 2q+1")
+	}
+
+	expG, expH := DeriveGenerators(p, q, seed)
+	if expG.Cmp(g) != 0 || expH.Cmp(h) != 0 {
+		return fmt.Errorf("g and h do not match the generators derived from the seed")
+	}
+
+	return nil
+}
+
+// CPZKPParams returns the protocol parameters described by the file
+func (f *ParamFile) CPZKPParams() (*CPZKPParams, error) {
+	return f.cpzkpParams(false)
+}
+
+func (f *ParamFile) cpzkpParams(insecure bool) (*CPZKPParams, error) {
+	p, q, g, h, err := f.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return newModPParams(p, q, g, h, insecure)
+}
+
+func (f *ParamFile) parse() (p, q, g, h *big.Int, err error) {
+	values := make([]*big.Int, 4)
+	for i, field := range []struct{ str, name string }{{f.P, "p"}, {f.Q, "q"}, {f.G, "g"}, {f.H, "h"}} {
+		v, err := util.ParseBigInt(field.str, field.name)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		values[i] = v
+	}
+	return values[0], values[1], values[2], values[3], nil
+}
+
+// WriteParamFile writes the parameter set as JSON to the given path
+func WriteParamFile(path string, f *ParamFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ReadParamFile reads a parameter set written by `WriteParamFile` and verifies its derivation
+func ReadParamFile(path string) (*ParamFile, error) {
+	return readParamFile(path, false)
+}
+
+func readParamFile(path string, insecure bool) (*ParamFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f ParamFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("error parsing parameter file %s: %v", path, err)
+	}
+
+	if err := f.verify(insecure); err != nil {
+		return nil, fmt.Errorf("parameter file %s failed verification: %w", path, err)
+	}
+
+	return &f, nil
+}
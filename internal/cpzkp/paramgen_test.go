@@ -0,0 +1,95 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateSafePrime checks that the generated `p = 2q+1` is a safe prime of the requested size
+func TestGenerateSafePrime(t *testing.T) {
+	p, q, err := GenerateSafePrime(rand.Reader, 192)
+	if err != nil {
+		t.Fatalf("error generating safe prime: %v", err)
+	}
+
+	if p.BitLen() != 192 {
+		t.Errorf("expected a 192-bit prime, got %d bits", p.BitLen())
+	}
+
+	if !q.ProbablyPrime(20) || !p.ProbablyPrime(20) {
+		t.Errorf("p = 2q+1 is not a safe prime")
+	}
+
+	if new(big.Int).Add(new(big.Int).Lsh(q, 1), big.NewInt(1)).Cmp(p) != 0 {
+		t.Errorf("p != 2q+1")
+	}
+}
+
+// TestParamFile checks that a generated parameter file can be re-derived from its seed,
+// that tampering is detected, and that the loaded parameters run the protocol
+func TestParamFile(t *testing.T) {
+	paramFile, err := GenerateParamFile(192, []byte("nothing up my sleeve"))
+	if err != nil {
+		t.Fatalf("error generating parameter file: %v", err)
+	}
+
+	if err := paramFile.VerifyInsecure(); err != nil {
+		t.Fatalf("expected a valid parameter file, got: %v", err)
+	}
+
+	// 192-bit parameters are only accepted as an explicit opt-in
+	if err := paramFile.Verify(); !errors.Is(err, ErrParamTooSmall) {
+		t.Errorf("expected the 192-bit parameter file to be too small, got: %v", err)
+	}
+
+	// Anyone re-deriving `g` and `h` from the seed gets the same generators
+	p, _ := new(big.Int).SetString(paramFile.P, 10)
+	q, _ := new(big.Int).SetString(paramFile.Q, 10)
+	g, h := DeriveGenerators(p, q, []byte("nothing up my sleeve"))
+	if g.String() != paramFile.G || h.String() != paramFile.H {
+		t.Errorf("generators do not match the seed derivation")
+	}
+
+	// A planted `h` is detected
+	tampered := *paramFile
+	tampered.H = new(big.Int).Exp(g, big.NewInt(42), p).String()
+	if err := tampered.VerifyInsecure(); err == nil {
+		t.Errorf("expected tampered parameter file to fail verification")
+	}
+
+	path := filepath.Join(t.TempDir(), "params.json")
+	if err := WriteParamFile(path, paramFile); err != nil {
+		t.Fatalf("error writing parameter file: %v", err)
+	}
+
+	if _, err := (&CPZKP{Group: GroupModP, ParamFile: path}).InitCPZKPParams(); !errors.Is(err, ErrParamTooSmall) {
+		t.Errorf("expected loading the 192-bit parameter file to fail, got: %v", err)
+	}
+
+	cpZKP := &CPZKP{Group: GroupModP, ParamFile: path, Insecure: true}
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error loading parameter file: %v", err)
+	}
+
+	prover := NewProver(big.NewInt(123456789))
+	y1, y2 := prover.GenerateYValues(params)
+	k, r1, r2, err := prover.CreateProofCommitment(params)
+	if err != nil {
+		t.Fatalf("error creating proof commitment: %v", err)
+	}
+
+	verifier := Verifier{}
+	c, err := verifier.CreateProofChallenge(params)
+	if err != nil {
+		t.Fatalf("error creating challenge: %v", err)
+	}
+
+	s := prover.CreateProofChallengeResponse(k, c, params)
+	if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+		t.Errorf("proof validation failed with the loaded parameters")
+	}
+}
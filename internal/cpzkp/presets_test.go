@@ -0,0 +1,47 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestPresetParams checks that every preset passes validation, that `h` can be
+// re-derived from the preset name, and that the protocol runs over the preset
+func TestPresetParams(t *testing.T) {
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			params, err := NewPresetParams(name)
+			if err != nil {
+				t.Fatalf("error loading preset: %v", err)
+			}
+
+			grp := params.Group().(*ModPGroup)
+			h := DeriveGeneratorH(grp.P(), grp.Order(), []byte(name))
+			if h.String() != params.H().String() {
+				t.Errorf("h does not match the derivation from the preset name")
+			}
+
+			prover := NewProver(big.NewInt(123456789))
+			y1, y2 := prover.GenerateYValues(params)
+			k, r1, r2, err := prover.CreateProofCommitment(params)
+			if err != nil {
+				t.Fatalf("error creating proof commitment: %v", err)
+			}
+
+			verifier := Verifier{}
+			c, err := verifier.CreateProofChallenge(params)
+			if err != nil {
+				t.Fatalf("error creating challenge: %v", err)
+			}
+
+			s := prover.CreateProofChallengeResponse(k, c, params)
+			if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+				t.Errorf("proof validation failed over preset %s", name)
+			}
+		})
+	}
+
+	if _, err := NewPresetParams("modp1024"); err == nil {
+		t.Errorf("expected an error for an unknown preset")
+	}
+}
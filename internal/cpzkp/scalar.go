@@ -0,0 +1,51 @@
+package cp_zkp
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// Upper bound on the rejected candidates of `RandomScalar`. Every candidate is accepted
+// with probability above 1/2, so honest readers never get close to it; a broken reader
+// that keeps returning zeros fails instead of looping forever.
+const maxScalarAttempts = 128
+
+// ErrScalarSampling is returned when no scalar could be drawn from the reader
+var ErrScalarSampling = errors.New("too many rejected candidates while sampling a scalar")
+
+// RandomScalar draws a uniform non-zero scalar in Z_q, i.e. in [1, q-1], from `random`
+// (`crypto/rand` if nil).
+// It reads |q| bits at a time and rejects the candidates that are zero or >= q, so every
+// accepted value is equally likely: no modular reduction biases the result.
+func RandomScalar(random io.Reader, q *big.Int) (*big.Int, error) {
+	random = randomReader(random)
+
+	bits := q.BitLen()
+	buf := make([]byte, (bits+7)/8)
+
+	// mask the excess bits of the most significant byte
+	mask := byte(0xff >> (8*len(buf) - bits))
+
+	for i := 0; i < maxScalarAttempts; i++ {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, err
+		}
+		buf[0] &= mask
+
+		k := new(big.Int).SetBytes(buf)
+		if k.Sign() != 0 && k.Cmp(q) < 0 {
+			return k, nil
+		}
+	}
+	return nil, ErrScalarSampling
+}
+
+// randomReader returns the injected source of randomness or `crypto/rand`
+func randomReader(random io.Reader) io.Reader {
+	if random == nil {
+		return rand.Reader
+	}
+	return random
+}
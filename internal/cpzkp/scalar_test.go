@@ -0,0 +1,132 @@
+package cp_zkp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+)
+
+// zeroReader returns an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
+
+// TestRandomScalarDistribution draws many scalars from small moduli and checks with a
+// chi-square test that every value of [1, q-1] is equally likely and that 0 never occurs.
+// The reader is seeded, so the test is deterministic.
+func TestRandomScalarDistribution(t *testing.T) {
+	for _, tc := range []struct {
+		q int64
+		// chi-square critical value at p = 0.001 for q-2 degrees of freedom
+		critical float64
+	}{
+		{q: 11, critical: 27.88},
+		{q: 131, critical: 181.99},
+		{q: 257, critical: 330.52},
+	} {
+		q := big.NewInt(tc.q)
+		random := mrand.New(mrand.NewSource(tc.q))
+
+		const samplesPerValue = 1000
+		n := samplesPerValue * (tc.q - 1)
+		counts := make([]int, tc.q)
+		for i := int64(0); i < n; i++ {
+			k, err := RandomScalar(random, q)
+			if err != nil {
+				t.Fatalf("q = %d: error sampling scalar: %v", tc.q, err)
+			}
+			if k.Sign() <= 0 || k.Cmp(q) >= 0 {
+				t.Fatalf("q = %d: scalar %v out of [1, q-1]", tc.q, k)
+			}
+			counts[k.Int64()]++
+		}
+
+		var chi2 float64
+		for _, count := range counts[1:] {
+			d := float64(count - samplesPerValue)
+			chi2 += d * d / samplesPerValue
+		}
+		if chi2 > tc.critical {
+			t.Errorf("q = %d: chi-square %.2f above %.2f, the scalars are not uniform", tc.q, chi2, tc.critical)
+		}
+	}
+}
+
+// TestRandomScalarRejection checks that zero and out of range candidates are rejected
+// instead of being reduced, and that a failing reader is reported
+func TestRandomScalarRejection(t *testing.T) {
+	q := big.NewInt(11)
+
+	// 0 is rejected, 0x0b = 11 is rejected, 0xf7 is masked to 4 bits: 7
+	k, err := RandomScalar(bytes.NewReader([]byte{0x00, 0x0b, 0xf7}), q)
+	if err != nil {
+		t.Fatalf("error sampling scalar: %v", err)
+	}
+	if k.Int64() != 7 {
+		t.Errorf("expected 7, got %v", k)
+	}
+
+	if _, err := RandomScalar(bytes.NewReader([]byte{0x00}), q); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF from an exhausted reader, got %v", err)
+	}
+
+	if _, err := RandomScalar(zeroReader{}, q); !errors.Is(err, ErrScalarSampling) {
+		t.Errorf("expected ErrScalarSampling from a reader of zeros, got %v", err)
+	}
+}
+
+// TestInjectedRandomness checks that the prover and the verifier draw their scalars from
+// the injected reader and that the resulting proof verifies
+func TestInjectedRandomness(t *testing.T) {
+	cpZKP, err := NewCPZKPWithGroup(GroupModP)
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	run := func(seed int64) (k, c *big.Int) {
+		prover := NewProver(big.NewInt(123456789))
+		prover.Rand = mrand.New(mrand.NewSource(seed))
+		verifier := Verifier{Rand: mrand.New(mrand.NewSource(seed + 1))}
+
+		y1, y2 := prover.GenerateYValues(params)
+		k, r1, r2, err := prover.CreateProofCommitment(params)
+		if err != nil {
+			t.Fatalf("error creating proof commitment: %v", err)
+		}
+
+		c, err = verifier.CreateProofChallenge(params)
+		if err != nil {
+			t.Fatalf("error creating challenge: %v", err)
+		}
+
+		s := prover.CreateProofChallengeResponse(k, c, params)
+		if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+			t.Fatalf("expected the proof to verify")
+		}
+		return k, c
+	}
+
+	k1, c1 := run(1)
+	k2, c2 := run(1)
+	if k1.Cmp(k2) != 0 || c1.Cmp(c2) != 0 {
+		t.Errorf("expected the same scalars from the same reader")
+	}
+
+	k3, c3 := run(2)
+	if k1.Cmp(k3) == 0 || c1.Cmp(c3) == 0 {
+		t.Errorf("expected different scalars from a different reader")
+	}
+}
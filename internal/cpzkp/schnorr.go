@@ -0,0 +1,118 @@
+package cp_zkp
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+)
+
+// Identification protocols an account can be registered with
+const (
+	// ProtocolChaumPedersen proves that log_g(y1) = log_h(y2) = x (the default)
+	ProtocolChaumPedersen = "chaum-pedersen"
+
+	// ProtocolSchnorr proves knowledge of x = log_g(y) over the single base `g`,
+	// at about half the cost: no `h`, `y2` or `r2` are involved
+	ProtocolSchnorr = "schnorr"
+
+	// Domain separation tag for the Fiat-Shamir challenge of Schnorr proofs
+	schnorrDST = "zkp_auth/cpzkp/schnorr/v1"
+)
+
+// ParseProtocol checks the name of an identification protocol. The empty name selects
+// Chaum-Pedersen, so that accounts registered before Schnorr mode keep working.
+func ParseProtocol(name string) (string, error) {
+	switch name {
+	case "", ProtocolChaumPedersen:
+		return ProtocolChaumPedersen, nil
+	case ProtocolSchnorr:
+		return ProtocolSchnorr, nil
+	default:
+		return "", fmt.Errorf("unsupported protocol %q", name)
+	}
+}
+
+// SchnorrProof is a self-contained non-interactive Schnorr proof of knowledge of log_g(y)
+type SchnorrProof struct {
+	R    Element
+	C, S *big.Int
+}
+
+// GenerateSchnorrY generates the public value y = g^x of a Schnorr account
+func (p *Prover) GenerateSchnorrY(params *CPZKPParams) (y Element) {
+	y = params.secretExpG(p.x)
+	log.Println("[grpcClient-Prover]: Generated `y` value")
+	return y
+}
+
+// CreateSchnorrCommitment: the prover selects a random value k and commits r = g^k.
+// The response to a challenge `c` is computed with `CreateProofChallengeResponse`,
+// s = (k - c * x) mod q, as in Chaum-Pedersen.
+func (p *Prover) CreateSchnorrCommitment(params *CPZKPParams) (k *big.Int, r Element, err error) {
+	return p.schnorrCommit(params, p.Context)
+}
+
+// schnorrCommit creates the commitment of a Schnorr proof bound to `context`
+func (p *Prover) schnorrCommit(params *CPZKPParams, context []byte) (k *big.Int, r Element, err error) {
+	k, err = p.nonce(params, context)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r = params.secretExpG(k)
+
+	log.Println("[grpcClient-Prover]: Created Schnorr commitment. Generated `k` and `r` values")
+	return k, r, nil
+}
+
+// VerifySchnorrProof checks r = g^s * y^c
+func (v *Verifier) VerifySchnorrProof(y, r Element, c, s *big.Int, params *CPZKPParams) bool {
+
+	defer log.Println("[grpcServer-Verifier]: Verified the generated Schnorr proof")
+
+	return ctEqual(params.expMul(params.g, gTable, s, y, c), r)
+}
+
+// CreateSchnorrNIProof creates a non-interactive Schnorr proof of knowledge of `x` bound to
+// `context`, with c = H(params, y, r, context)
+func (p *Prover) CreateSchnorrNIProof(params *CPZKPParams, context []byte) (*SchnorrProof, error) {
+	k, r, err := p.schnorrCommit(params, context)
+	if err != nil {
+		return nil, err
+	}
+
+	y := params.secretExpG(p.x)
+	c := schnorrChallenge(params, y, r, context)
+
+	return &SchnorrProof{
+		R: r,
+		C: c,
+		S: p.CreateProofChallengeResponse(k, c, params),
+	}, nil
+}
+
+// VerifySchnorrNIProof verifies a non-interactive Schnorr proof against the public `y`
+func (v *Verifier) VerifySchnorrNIProof(y Element, proof *SchnorrProof, context []byte, params *CPZKPParams) bool {
+	if proof == nil || proof.R == nil || proof.C == nil || proof.S == nil {
+		return false
+	}
+
+	c := schnorrChallenge(params, y, proof.R, context)
+	if c.Cmp(proof.C) != 0 {
+		log.Println("[grpcServer-Verifier]: Fiat-Shamir challenge mismatch")
+		return false
+	}
+
+	return v.VerifySchnorrProof(y, proof.R, c, proof.S, params)
+}
+
+// schnorrChallenge derives the challenge of a Schnorr proof from a domain-separated transcript.
+// The tag differs from the Chaum-Pedersen one, so a proof of one kind never verifies as the other.
+func schnorrChallenge(params *CPZKPParams, y, r Element, context []byte) *big.Int {
+	t := NewTranscript(schnorrDST)
+	t.AppendParams(params)
+	t.AppendElements("y", y)
+	t.AppendElements("r", r)
+	t.AppendMessage("context", context)
+	return t.Challenge("c", params.group.Order())
+}
@@ -0,0 +1,103 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"testing"
+
+	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
+	"github.com/srinathLN7/zkp_auth/lib/util"
+)
+
+// TestSchnorrProtocol tests the correctness and soundness of the interactive and the
+// non-interactive Schnorr proofs over every group
+func TestSchnorrProtocol(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			x, err := util.ParseBigInt(sys_config.CPZKP_TEST_X_CORRECT, "x")
+			if err != nil {
+				t.Fatalf("error parsing the secret value `x` to big integer")
+			}
+
+			prover := NewProver(x)
+			y := prover.GenerateSchnorrY(params)
+
+			k, r, err := prover.CreateSchnorrCommitment(params)
+			if err != nil {
+				t.Fatalf("error creating commitment: %v", err)
+			}
+
+			verifier := Verifier{}
+			c, err := verifier.CreateProofChallenge(params)
+			if err != nil {
+				t.Fatalf("error creating challenge: %v", err)
+			}
+
+			s := prover.CreateProofChallengeResponse(k, c, params)
+			if !verifier.VerifySchnorrProof(y, r, c, s, params) {
+				t.Fatalf("expected valid proof, got invalid")
+			}
+
+			// A prover with the wrong secret fails
+			wrong := NewProver(new(big.Int).Add(x, big.NewInt(1)))
+			if verifier.VerifySchnorrProof(y, r, c, wrong.CreateProofChallengeResponse(k, c, params), params) {
+				t.Errorf("expected proof with a wrong secret to fail")
+			}
+
+			context := []byte("message-id: 42")
+			proof, err := prover.CreateSchnorrNIProof(params, context)
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+
+			if !verifier.VerifySchnorrNIProof(y, proof, context, params) {
+				t.Fatalf("expected valid non-interactive proof, got invalid")
+			}
+
+			if verifier.VerifySchnorrNIProof(y, proof, []byte("message-id: 43"), params) {
+				t.Errorf("expected proof to fail under a different context")
+			}
+
+			otherY := NewProver(big.NewInt(7)).GenerateSchnorrY(params)
+			if verifier.VerifySchnorrNIProof(otherY, proof, context, params) {
+				t.Errorf("expected proof to fail for another public value")
+			}
+
+			// A Chaum-Pedersen proof does not pass as a Schnorr proof
+			cpProof, err := prover.CreateNIProof(params, context)
+			if err != nil {
+				t.Fatalf("error creating proof: %v", err)
+			}
+			if verifier.VerifySchnorrNIProof(y, &SchnorrProof{R: cpProof.R1, C: cpProof.C, S: cpProof.S}, context, params) {
+				t.Errorf("expected a Chaum-Pedersen proof to fail as a Schnorr proof")
+			}
+		})
+	}
+}
+
+func TestParseProtocol(t *testing.T) {
+	for name, want := range map[string]string{
+		"":                    ProtocolChaumPedersen,
+		ProtocolChaumPedersen: ProtocolChaumPedersen,
+		ProtocolSchnorr:       ProtocolSchnorr,
+	} {
+		got, err := ParseProtocol(name)
+		if err != nil || got != want {
+			t.Errorf("ParseProtocol(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseProtocol("okamoto"); err == nil {
+		t.Errorf("expected an unknown protocol to be rejected")
+	}
+}
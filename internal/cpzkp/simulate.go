@@ -0,0 +1,50 @@
+package cp_zkp
+
+import (
+	"io"
+	"math/big"
+)
+
+// ProofTranscript is the transcript (r1, r2, c, s) of one run of the interactive protocol
+type ProofTranscript struct {
+	R1, R2 Element
+	C, S   *big.Int
+}
+
+// SimulateTranscript produces an accepting transcript for the public values (y1, y2)
+// without knowing `x`, as the zero-knowledge simulator of the protocol does. It draws the
+// challenge `c` and the response `s` first and solves the verification equations for the
+// commitment: r1 = g^s * y1^c and r2 = h^s * y2^c.
+//
+// A real commitment g^k never is the identity because k != 0, so the candidates with an
+// identity `r1` are rejected. The simulated transcripts then follow exactly the same
+// distribution as the transcripts of an honest prover and an honest verifier: the
+// transcripts reveal nothing that the public values did not already reveal.
+func SimulateTranscript(params *CPZKPParams, y1, y2 Element, random io.Reader) (*ProofTranscript, error) {
+	grp := params.group
+	q := grp.Order()
+
+	// The challenge is a uniform non-zero scalar, as drawn by `CreateProofChallenge`
+	c, err := RandomScalar(random, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < maxScalarAttempts; i++ {
+		// `s` is uniform in [0, q-1]: a non-zero scalar below q+1, minus one
+		s, err := RandomScalar(random, new(big.Int).Add(q, big.NewInt(1)))
+		if err != nil {
+			return nil, err
+		}
+		s.Sub(s, big.NewInt(1))
+
+		r1 := grp.Mul(grp.Exp(params.g, s), grp.Exp(y1, c))
+		if grp.Equal(r1, grp.Identity()) {
+			continue
+		}
+
+		r2 := grp.Mul(grp.Exp(params.h, s), grp.Exp(y2, c))
+		return &ProofTranscript{R1: r1, R2: r2, C: c, S: s}, nil
+	}
+	return nil, ErrScalarSampling
+}
@@ -0,0 +1,135 @@
+package cp_zkp
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	mrand "math/rand"
+	"os"
+	"testing"
+)
+
+// toyParams returns the order 11 subgroup of Z_23^* generated by g = 4, with h = 9. It is
+// small enough to enumerate every possible transcript.
+func toyParams() *CPZKPParams {
+	grp := NewModPGroup(big.NewInt(23), big.NewInt(11), big.NewInt(4))
+	return NewCPZKPParams(grp, grp.Generator(), grp.NewElement(big.NewInt(9)))
+}
+
+func transcriptKey(t *ProofTranscript) string {
+	return fmt.Sprintf("%v,%v,%v,%v", t.R1, t.R2, t.C, t.S)
+}
+
+// TestSimulatorDistribution checks that the transcripts of the simulator, which does
+// not know `x`, are distributed exactly as the transcripts of the real protocol: both
+// only take the values of the real support and are uniform over it
+func TestSimulatorDistribution(t *testing.T) {
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	params := toyParams()
+	q := params.Group().Order().Int64()
+
+	prover := NewProver(big.NewInt(3))
+	y1, y2 := prover.GenerateYValues(params)
+
+	// Every real transcript: one per non-zero nonce `k` and non-zero challenge `c`
+	support := make(map[string]bool)
+	for k := int64(1); k < q; k++ {
+		for c := int64(1); c < q; c++ {
+			kk, cc := big.NewInt(k), big.NewInt(c)
+			support[transcriptKey(&ProofTranscript{
+				R1: params.Group().Exp(params.G(), kk),
+				R2: params.Group().Exp(params.H(), kk),
+				C:  cc,
+				S:  prover.CreateProofChallengeResponse(kk, cc, params),
+			})] = true
+		}
+	}
+	if len(support) != int((q-1)*(q-1)) {
+		t.Fatalf("expected %d distinct real transcripts, got %d", (q-1)*(q-1), len(support))
+	}
+
+	const samplesPerTranscript = 200
+	n := samplesPerTranscript * len(support)
+
+	honest := func() *ProofTranscript {
+		k, r1, r2, err := prover.CreateProofCommitment(params)
+		if err != nil {
+			t.Fatalf("error creating proof commitment: %v", err)
+		}
+		c, err := (&Verifier{Rand: prover.Rand}).CreateProofChallenge(params)
+		if err != nil {
+			t.Fatalf("error creating challenge: %v", err)
+		}
+		return &ProofTranscript{R1: r1, R2: r2, C: c, S: prover.CreateProofChallengeResponse(k, c, params)}
+	}
+
+	random := mrand.New(mrand.NewSource(17))
+	simulated := func() *ProofTranscript {
+		tr, err := SimulateTranscript(params, y1, y2, random)
+		if err != nil {
+			t.Fatalf("error simulating transcript: %v", err)
+		}
+		return tr
+	}
+
+	prover.Rand = mrand.New(mrand.NewSource(7))
+	for name, sample := range map[string]func() *ProofTranscript{"real": honest, "simulated": simulated} {
+		counts := make(map[string]int)
+		for i := 0; i < n; i++ {
+			key := transcriptKey(sample())
+			if !support[key] {
+				t.Fatalf("%s transcript %s is not a possible real transcript", name, key)
+			}
+			counts[key]++
+		}
+
+		// chi-square against the uniform distribution over the support; the critical
+		// value is for p = 0.001 and 99 degrees of freedom
+		var chi2 float64
+		for key := range support {
+			d := float64(counts[key] - samplesPerTranscript)
+			chi2 += d * d / samplesPerTranscript
+		}
+		if chi2 > 148.23 {
+			t.Errorf("%s transcripts: chi-square %.2f above 148.23, not uniform over the real transcripts", name, chi2)
+		}
+	}
+}
+
+// TestSimulatedTranscriptsVerify checks that the simulator produces accepting transcripts
+// for the public values of an unknown secret over every group
+func TestSimulatedTranscriptsVerify(t *testing.T) {
+	for _, group := range []string{GroupModP, GroupP256, GroupRistretto255} {
+		t.Run(group, func(t *testing.T) {
+			cpZKP, err := NewCPZKPWithGroup(group)
+			if err != nil {
+				t.Fatalf("error creating CPZKP instance: %v", err)
+			}
+
+			params, err := cpZKP.InitCPZKPParams()
+			if err != nil {
+				t.Fatalf("error generating ZKP parameters: %v", err)
+			}
+
+			x, err := RandomScalar(nil, params.Group().Order())
+			if err != nil {
+				t.Fatalf("error sampling secret: %v", err)
+			}
+			y1, y2 := NewProver(x).GenerateYValues(params)
+
+			verifier := Verifier{}
+			for i := 0; i < 8; i++ {
+				tr, err := SimulateTranscript(params, y1, y2, nil)
+				if err != nil {
+					t.Fatalf("error simulating transcript: %v", err)
+				}
+				if !verifier.VerifyProof(y1, y2, tr.R1, tr.R2, tr.C, tr.S, params) {
+					t.Fatalf("expected the simulated transcript to verify")
+				}
+			}
+		})
+	}
+}
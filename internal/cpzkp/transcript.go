@@ -0,0 +1,175 @@
+package cp_zkp
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+	"io"
+	"log"
+	"math/big"
+)
+
+// Transcript labels used by the protocol
+const (
+	// AuthTranscriptLabel binds the interactive login challenge to its context
+	AuthTranscriptLabel = "zkp_auth/cpzkp/auth/v1"
+
+	// SchnorrAuthTranscriptLabel binds the interactive login challenge of a Schnorr account to its context
+	SchnorrAuthTranscriptLabel = "zkp_auth/cpzkp/schnorr-auth/v1"
+
+	// GroupAuthTranscriptLabel binds the anonymous group login challenge to its context
+	GroupAuthTranscriptLabel = "zkp_auth/cpzkp/group-auth/v1"
+
+	// Size of the fresh randomness mixed into interactive challenges
+	challengeNonceSize = 32
+)
+
+// Transcript accumulates the labelled public values of a proof in a running SHA-512 hash.
+// Every label and message is length-prefixed, so two different sequences of appends can
+// never produce the same hash input. Challenges derived from a transcript are bound to
+// everything that was appended before, e.g. the parameters, the user, the server identity
+// and the commitments, and are meaningless in any other context.
+type Transcript struct {
+	hash hash.Hash
+}
+
+// NewTranscript starts a transcript under the given domain separation label
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{hash: sha512.New()}
+	t.AppendMessage("dst", []byte(label))
+	return t
+}
+
+// AppendMessage appends a labelled byte string
+func (t *Transcript) AppendMessage(label string, msg []byte) {
+	t.write([]byte(label))
+	t.write(msg)
+}
+
+// AppendElements appends the canonical encodings of the given group elements under one label
+func (t *Transcript) AppendElements(label string, elements ...Element) {
+	for _, e := range elements {
+		t.AppendMessage(label, e.Bytes())
+	}
+}
+
+// AppendParams appends the public parameters so that challenges are bound to the group and generators
+func (t *Transcript) AppendParams(params *CPZKPParams) {
+	t.AppendMessage("params", params.encode())
+}
+
+// Challenge derives a uniform non-zero scalar in Z_q from the current state of the transcript.
+// The label is appended first, so several challenges can be drawn from one transcript.
+// The candidates are read from a counter-mode SHA-512 stream seeded with the transcript and
+// rejected as in `RandomScalar`, so `c` is unbiased for every size of `q`, including the
+// 3071-bit order of `ffdhe3072`, which a single 512-bit hash reduced mod q would not cover.
+func (t *Transcript) Challenge(label string, q *big.Int) *big.Int {
+	t.AppendMessage("challenge", []byte(label))
+	stream := &challengeStream{seed: t.hash.Sum(nil)}
+	for {
+		// The stream never fails, so only a run of rejected candidates ends up here
+		if c, err := RandomScalar(stream, q); err == nil {
+			return c
+		}
+	}
+}
+
+// challengeStream expands a transcript hash into the blocks SHA-512(seed || counter)
+type challengeStream struct {
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+func (s *challengeStream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.block) == 0 {
+			var ctr [8]byte
+			binary.BigEndian.PutUint64(ctr[:], s.counter)
+			s.counter++
+			h := sha512.New()
+			h.Write(s.seed)
+			h.Write(ctr[:])
+			s.block = h.Sum(nil)
+		}
+		copied := copy(p[n:], s.block)
+		s.block = s.block[copied:]
+		n += copied
+	}
+	return n, nil
+}
+
+func (t *Transcript) write(b []byte) {
+	var l [4]byte
+	binary.BigEndian.PutUint32(l[:], uint32(len(b)))
+	t.hash.Write(l[:])
+	t.hash.Write(b)
+}
+
+// NewAuthTranscript starts the transcript of an interactive login. It binds the parameters,
+// the identity of the server, the user, the authentication session, the user's registered
+// public values and the prover's commitments.
+func NewAuthTranscript(params *CPZKPParams, serverID, user, authID string, y1, y2, r1, r2 Element) *Transcript {
+	t := NewTranscript(AuthTranscriptLabel)
+	t.AppendParams(params)
+	t.AppendMessage("server", []byte(serverID))
+	t.AppendMessage("user", []byte(user))
+	t.AppendMessage("auth_id", []byte(authID))
+	t.AppendElements("y", y1, y2)
+	t.AppendElements("r", r1, r2)
+	return t
+}
+
+// NewSchnorrAuthTranscript starts the transcript of an interactive login of a Schnorr account.
+// It binds the same context as `NewAuthTranscript`, with the single public value `y` and commitment `r`.
+func NewSchnorrAuthTranscript(params *CPZKPParams, serverID, user, authID string, y, r Element) *Transcript {
+	t := NewTranscript(SchnorrAuthTranscriptLabel)
+	t.AppendParams(params)
+	t.AppendMessage("server", []byte(serverID))
+	t.AppendMessage("user", []byte(user))
+	t.AppendMessage("auth_id", []byte(authID))
+	t.AppendElements("y", y)
+	t.AppendElements("r", r)
+	return t
+}
+
+// NewGroupAuthTranscript starts the transcript of an anonymous group login. It binds the
+// parameters, the identity of the server, the group, the authentication session and, in
+// order, the public values and commitments of every listed member.
+func NewGroupAuthTranscript(params *CPZKPParams, serverID, group, authID string, statements []Statement, commitments []Commitment) *Transcript {
+	t := NewTranscript(GroupAuthTranscriptLabel)
+	t.AppendParams(params)
+	t.AppendMessage("server", []byte(serverID))
+	t.AppendMessage("group", []byte(group))
+	t.AppendMessage("auth_id", []byte(authID))
+	for i := range statements {
+		t.AppendElements("y", statements[i].Y1, statements[i].Y2)
+		t.AppendElements("r", commitments[i].R1, commitments[i].R2)
+	}
+	return t
+}
+
+// CreateContextChallenge: verifier creates an interactive challenge bound to the transcript.
+// Fresh randomness is appended before deriving `c`, so the challenge stays unpredictable to
+// the prover while being tied to the context (user, server, session, commitments) it was issued in.
+// The nonce is returned so that the derivation can be re-checked later.
+func (v *Verifier) CreateContextChallenge(params *CPZKPParams, t *Transcript) (c *big.Int, nonce []byte, err error) {
+	nonce = make([]byte, challengeNonceSize)
+	if _, err := io.ReadFull(randomReader(v.Rand), nonce); err != nil {
+		return nil, nil, err
+	}
+
+	t.AppendMessage("nonce", nonce)
+	c = t.Challenge("c", params.group.Order())
+
+	log.Println("[grpcServer-Verifier]: Created context-bound proof challenge. Generated `c` value")
+	return c, nonce, nil
+}
+
+// VerifyContextChallenge re-derives the challenge from the transcript and the nonce it was issued
+// with, and reports whether it equals `c`. It fails for a transcript of any other context.
+func (v *Verifier) VerifyContextChallenge(params *CPZKPParams, t *Transcript, nonce []byte, c *big.Int) bool {
+	t.AppendMessage("nonce", nonce)
+	return c != nil && t.Challenge("c", params.group.Order()).Cmp(c) == 0
+}
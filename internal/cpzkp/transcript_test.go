@@ -0,0 +1,119 @@
+package cp_zkp
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestContextChallenge tests that interactive challenges are bound to the server,
+// the user, the authentication session and the commitments they were issued for
+func TestContextChallenge(t *testing.T) {
+	cpZKP, err := NewCPZKPWithGroup(GroupP256)
+	if err != nil {
+		t.Fatalf("error creating CPZKP instance: %v", err)
+	}
+
+	params, err := cpZKP.InitCPZKPParams()
+	if err != nil {
+		t.Fatalf("error generating ZKP parameters: %v", err)
+	}
+
+	prover := NewProver(big.NewInt(123456789))
+	y1, y2 := prover.GenerateYValues(params)
+	k, r1, r2, err := prover.CreateProofCommitment(params)
+	if err != nil {
+		t.Fatalf("error creating proof commitment: %v", err)
+	}
+
+	verifier := Verifier{}
+	transcript := func(serverID, user, authID string) *Transcript {
+		return NewAuthTranscript(params, serverID, user, authID, y1, y2, r1, r2)
+	}
+
+	c, nonce, err := verifier.CreateContextChallenge(params, transcript("server-a", "alice", "auth-1"))
+	if err != nil {
+		t.Fatalf("error creating challenge: %v", err)
+	}
+
+	if !verifier.VerifyContextChallenge(params, transcript("server-a", "alice", "auth-1"), nonce, c) {
+		t.Fatalf("expected the challenge to match its own context")
+	}
+
+	// The same nonce yields a different challenge in any other context
+	for _, other := range []*Transcript{
+		transcript("server-b", "alice", "auth-1"),
+		transcript("server-a", "bob", "auth-1"),
+		transcript("server-a", "alice", "auth-2"),
+		NewAuthTranscript(params, "server-a", "alice", "auth-1", y1, y2, r2, r1),
+	} {
+		if verifier.VerifyContextChallenge(params, other, nonce, c) {
+			t.Errorf("expected the challenge to be rejected in another context")
+		}
+	}
+
+	// The bound challenge is still answered with the usual response
+	s := prover.CreateProofChallengeResponse(k, c, params)
+	if !verifier.VerifyProof(y1, y2, r1, r2, c, s, params) {
+		t.Errorf("proof validation failed for a context-bound challenge")
+	}
+}
+
+// TestChallengeLargeOrder checks that transcript challenges cover the whole of Z_q for a
+// 3071-bit order, where a single 512-bit hash would only reach a tiny corner of it, and that
+// they are never zero
+func TestChallengeLargeOrder(t *testing.T) {
+	params, err := NewPresetParams(PresetFFDHE3072)
+	if err != nil {
+		t.Fatalf("error loading preset: %v", err)
+	}
+	q := params.Group().Order()
+	half := new(big.Int).Rsh(q, 1)
+
+	upper := 0
+	for i := 0; i < 64; i++ {
+		tr := NewTranscript("test")
+		tr.AppendMessage("i", []byte{byte(i)})
+		c := tr.Challenge("c", q)
+		if c.Sign() <= 0 || c.Cmp(q) >= 0 {
+			t.Fatalf("challenge out of range: %v", c)
+		}
+		if c.BitLen() <= 512 {
+			t.Errorf("challenge %d has only %d bits", i, c.BitLen())
+		}
+		if c.Cmp(half) > 0 {
+			upper++
+		}
+	}
+	if upper < 16 || upper > 48 {
+		t.Errorf("expected about half of the challenges above q/2, got %d of 64", upper)
+	}
+
+	// An interactive challenge over the preset is still answered with the usual response
+	prover := NewProver(big.NewInt(123456789))
+	y1, y2 := prover.GenerateYValues(params)
+	k, r1, r2, err := prover.CreateProofCommitment(params)
+	if err != nil {
+		t.Fatalf("error creating proof commitment: %v", err)
+	}
+
+	verifier := Verifier{}
+	c, nonce, err := verifier.CreateContextChallenge(params, NewAuthTranscript(params, "server", "alice", "auth-1", y1, y2, r1, r2))
+	if err != nil {
+		t.Fatalf("error creating challenge: %v", err)
+	}
+	if !verifier.VerifyContextChallenge(params, NewAuthTranscript(params, "server", "alice", "auth-1", y1, y2, r1, r2), nonce, c) {
+		t.Fatalf("expected the challenge to match its own context")
+	}
+	if !verifier.VerifyProof(y1, y2, r1, r2, c, prover.CreateProofChallengeResponse(k, c, params), params) {
+		t.Errorf("proof validation failed for a %d-bit challenge", c.BitLen())
+	}
+
+	// The smallest orders leave a single non-zero candidate, which is always the one returned
+	for i := 0; i < 16; i++ {
+		tr := NewTranscript("test")
+		tr.AppendMessage("i", []byte{byte(i)})
+		if c := tr.Challenge("c", big.NewInt(2)); c.Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("expected the challenge 1 mod 2, got %v", c)
+		}
+	}
+}
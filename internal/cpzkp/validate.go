@@ -0,0 +1,171 @@
+package cp_zkp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Minimum sizes accepted for the `modp` group, those of the smallest RFC presets and of the
+// 2048/224 groups of FIPS 186
+const (
+	MinModPBits     = 2048
+	MinSubgroupBits = 224
+)
+
+// Sanity floors of the `Insecure` variants, which only reject truncated parameters.
+// They accept the small groups used by tests and must not be used in production.
+const (
+	InsecureMinModPBits     = 160
+	InsecureMinSubgroupBits = 160
+)
+
+// Reasons reported by `ErrInvalidParams`. Use `errors.Is` to test for a specific reason.
+var (
+	ErrParamMissing    = errors.New("parameter is missing")
+	ErrParamOutOfRange = errors.New("parameter is out of range")
+	ErrParamTooSmall   = errors.New("parameter is too small")
+	ErrParamNotPrime   = errors.New("parameter is not prime")
+	ErrOrderMismatch   = errors.New("q does not divide p-1")
+	ErrNotGenerator    = errors.New("parameter does not generate the order q subgroup")
+	ErrGeneratorsEqual = errors.New("g and h must be distinct")
+)
+
+// ErrInvalidParams is returned when the protocol parameters fail validation.
+// `Param` names the offending parameter (`p`, `q`, `g` or `h`).
+type ErrInvalidParams struct {
+	Param  string
+	Reason error
+}
+
+func (e ErrInvalidParams) Error() string {
+	return fmt.Sprintf("invalid zkp parameter %s: %v", e.Param, e.Reason)
+}
+
+func (e ErrInvalidParams) Unwrap() error {
+	return e.Reason
+}
+
+// NewModPParams validates the `modp` parameters and returns them ready to use.
+// It checks that `p` and `q` are primes of sufficient size, that `q | p-1`, and that
+// `g` and `h` are distinct generators of the order `q` subgroup of Z_p^*.
+func NewModPParams(p, q, g, h *big.Int) (*CPZKPParams, error) {
+	return newModPParams(p, q, g, h, false)
+}
+
+// NewModPParamsInsecure is `NewModPParams` with the floors lowered to `InsecureMinModPBits`
+// and `InsecureMinSubgroupBits`. It is meant for tests only.
+func NewModPParamsInsecure(p, q, g, h *big.Int) (*CPZKPParams, error) {
+	return newModPParams(p, q, g, h, true)
+}
+
+func newModPParams(p, q, g, h *big.Int, insecure bool) (*CPZKPParams, error) {
+	if err := validateModP(p, q, g, h, insecure); err != nil {
+		return nil, err
+	}
+
+	grp := NewModPGroup(p, q, g)
+	return NewCPZKPParams(grp, grp.Generator(), grp.NewElement(h)), nil
+}
+
+// ValidateParams checks already constructed parameters, e.g. after loading them
+// from a file or receiving them from the network. For every group it checks that
+// `g` and `h` are distinct elements of order `q`; for the `modp` group it also
+// checks the primality and size of `p` and `q`.
+func ValidateParams(params *CPZKPParams) error {
+	return validateParams(params, false)
+}
+
+// ValidateParamsInsecure is `ValidateParams` with the `modp` floors lowered to
+// `InsecureMinModPBits` and `InsecureMinSubgroupBits`. It is meant for tests only.
+func ValidateParamsInsecure(params *CPZKPParams) error {
+	return validateParams(params, true)
+}
+
+func validateParams(params *CPZKPParams, insecure bool) error {
+	if params == nil || params.group == nil || params.g == nil || params.h == nil {
+		return ErrInvalidParams{Param: "params", Reason: ErrParamMissing}
+	}
+
+	if grp, ok := params.group.(*ModPGroup); ok {
+		return validateModP(grp.p, grp.q, params.g.(modPElement).v, params.h.(modPElement).v, insecure)
+	}
+
+	// The curve groups have prime order `q` and their elements are members by construction
+	// (see `inSubgroup`), so every element but the identity generates the group
+	grp := params.group
+	for _, gen := range []struct {
+		name string
+		e    Element
+	}{{"g", params.g}, {"h", params.h}} {
+		if grp.Equal(gen.e, grp.Identity()) {
+			return ErrInvalidParams{Param: gen.name, Reason: ErrNotGenerator}
+		}
+	}
+
+	if grp.Equal(params.g, params.h) {
+		return ErrInvalidParams{Param: "h", Reason: ErrGeneratorsEqual}
+	}
+
+	return nil
+}
+
+func validateModP(p, q, g, h *big.Int, insecure bool) error {
+	for _, param := range []struct {
+		name string
+		v    *big.Int
+	}{{"p", p}, {"q", q}, {"g", g}, {"h", h}} {
+		if param.v == nil {
+			return ErrInvalidParams{Param: param.name, Reason: ErrParamMissing}
+		}
+		if param.v.Sign() <= 0 {
+			return ErrInvalidParams{Param: param.name, Reason: ErrParamOutOfRange}
+		}
+	}
+
+	minP, minQ := MinModPBits, MinSubgroupBits
+	if insecure {
+		minP, minQ = InsecureMinModPBits, InsecureMinSubgroupBits
+	}
+
+	if p.BitLen() < minP {
+		return ErrInvalidParams{Param: "p", Reason: ErrParamTooSmall}
+	}
+
+	if q.BitLen() < minQ {
+		return ErrInvalidParams{Param: "q", Reason: ErrParamTooSmall}
+	}
+
+	if !p.ProbablyPrime(20) {
+		return ErrInvalidParams{Param: "p", Reason: ErrParamNotPrime}
+	}
+
+	if !q.ProbablyPrime(20) {
+		return ErrInvalidParams{Param: "q", Reason: ErrParamNotPrime}
+	}
+
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+	if new(big.Int).Mod(pMinusOne, q).Sign() != 0 {
+		return ErrInvalidParams{Param: "q", Reason: ErrOrderMismatch}
+	}
+
+	// Since `q` is prime, any element other than 1 with e^q = 1 generates the subgroup
+	one := big.NewInt(1)
+	for _, gen := range []struct {
+		name string
+		v    *big.Int
+	}{{"g", g}, {"h", h}} {
+		if gen.v.Cmp(one) <= 0 || gen.v.Cmp(p) >= 0 {
+			return ErrInvalidParams{Param: gen.name, Reason: ErrParamOutOfRange}
+		}
+		if new(big.Int).Exp(gen.v, q, p).Cmp(one) != 0 {
+			return ErrInvalidParams{Param: gen.name, Reason: ErrNotGenerator}
+		}
+	}
+
+	if g.Cmp(h) == 0 {
+		return ErrInvalidParams{Param: "h", Reason: ErrGeneratorsEqual}
+	}
+
+	return nil
+}
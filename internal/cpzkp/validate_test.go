@@ -0,0 +1,99 @@
+package cp_zkp
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
+)
+
+// TestNewModPParams checks that malformed or weak parameters are rejected with the expected typed error
+func TestNewModPParams(t *testing.T) {
+	p, _ := new(big.Int).SetString(sys_config.CPZKP_PARAM_P, 10)
+	q, _ := new(big.Int).SetString(sys_config.CPZKP_PARAM_Q, 10)
+	g, _ := new(big.Int).SetString(sys_config.CPZKP_PARAM_G, 10)
+	h, _ := new(big.Int).SetString(sys_config.CPZKP_PARAM_H, 10)
+
+	params, err := NewModPParamsInsecure(p, q, g, h)
+	if err != nil {
+		t.Fatalf("expected the config parameters to be valid, got: %v", err)
+	}
+
+	// The 255-bit config parameters are only accepted as an explicit opt-in
+	if _, err := NewModPParams(p, q, g, h); !errors.Is(err, ErrParamTooSmall) {
+		t.Errorf("expected the config parameters to be too small, got: %v", err)
+	}
+	if err := ValidateParams(params); !errors.Is(err, ErrParamTooSmall) {
+		t.Errorf("expected ValidateParams to reject the config parameters, got: %v", err)
+	}
+
+	two := big.NewInt(2)
+
+	// p-1 has order 2, hence it lies outside the order q subgroup
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1))
+
+	tests := []struct {
+		name       string
+		p, q, g, h *big.Int
+		param      string
+		reason     error
+	}{
+		{"missing h", p, q, g, nil, "h", ErrParamMissing},
+		{"negative g", p, q, big.NewInt(-4), h, "g", ErrParamOutOfRange},
+		{"small p", big.NewInt(23), big.NewInt(11), big.NewInt(4), big.NewInt(9), "p", ErrParamTooSmall},
+		{"composite p", new(big.Int).Add(p, two), q, g, h, "p", ErrParamNotPrime},
+		{"composite q", p, new(big.Int).Add(q, two), g, h, "q", ErrParamNotPrime},
+		{"q does not divide p-1", p, nextPrime(q), g, h, "q", ErrOrderMismatch},
+		{"g is the identity", p, q, big.NewInt(1), h, "g", ErrParamOutOfRange},
+		{"g is not below p", p, q, new(big.Int).Add(p, g), h, "g", ErrParamOutOfRange},
+		{"g outside the subgroup", p, q, pMinusOne, h, "g", ErrNotGenerator},
+		{"h equals g", p, q, g, g, "h", ErrGeneratorsEqual},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewModPParamsInsecure(tc.p, tc.q, tc.g, tc.h)
+
+			var paramErr ErrInvalidParams
+			if !errors.As(err, &paramErr) {
+				t.Fatalf("expected ErrInvalidParams, got: %v", err)
+			}
+
+			if paramErr.Param != tc.param || !errors.Is(err, tc.reason) {
+				t.Errorf("expected %s: %v, got: %v", tc.param, tc.reason, err)
+			}
+		})
+	}
+}
+
+// TestValidateParamsCurves checks the generic validation used for the curve groups
+func TestValidateParamsCurves(t *testing.T) {
+	for _, name := range []string{GroupP256, GroupRistretto255} {
+		grp, err := NewGroup(name)
+		if err != nil {
+			t.Fatalf("error creating group: %v", err)
+		}
+
+		if err := ValidateParams(NewCPZKPParams(grp, grp.Generator(), grp.HashToElement([]byte("h")))); err != nil {
+			t.Errorf("%s: expected valid parameters, got: %v", name, err)
+		}
+
+		if err := ValidateParams(NewCPZKPParams(grp, grp.Generator(), grp.Generator())); !errors.Is(err, ErrGeneratorsEqual) {
+			t.Errorf("%s: expected ErrGeneratorsEqual, got: %v", name, err)
+		}
+
+		if err := ValidateParams(NewCPZKPParams(grp, grp.Identity(), grp.Generator())); !errors.Is(err, ErrNotGenerator) {
+			t.Errorf("%s: expected ErrNotGenerator, got: %v", name, err)
+		}
+	}
+}
+
+// nextPrime returns the smallest prime larger than q
+func nextPrime(q *big.Int) *big.Int {
+	next := new(big.Int).Add(q, big.NewInt(2))
+	for !next.ProbablyPrime(20) {
+		next.Add(next, big.NewInt(2))
+	}
+	return next
+}
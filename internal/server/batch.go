@@ -0,0 +1,99 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// Default maximum number of proofs verified together by the batch queue
+const defaultBatchSize = 64
+
+// verifyRequest is a proof waiting in the batch queue for its result
+type verifyRequest struct {
+	item   cp_zkp.BatchItem
+	result chan bool
+}
+
+// batchQueue collects the proofs submitted by concurrent `VerifyAuthentication` calls for
+// up to `window` (or until `size` proofs are pending) and verifies them with a single
+// `VerifyBatch` call. Each caller blocks until the result for its own proof is known.
+type batchQueue struct {
+	params *cp_zkp.CPZKPParams
+	window time.Duration
+	size   int
+
+	mu      sync.Mutex
+	pending []verifyRequest
+	timer   *time.Timer
+}
+
+func newBatchQueue(params *cp_zkp.CPZKPParams, window time.Duration, size int) *batchQueue {
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	return &batchQueue{
+		params: params,
+		window: window,
+		size:   size,
+	}
+}
+
+// Verify queues the proof and waits for the batch containing it to be verified
+func (b *batchQueue) Verify(item cp_zkp.BatchItem) bool {
+	req := verifyRequest{item: item, result: make(chan bool, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, req)
+	switch {
+	case len(b.pending) >= b.size:
+		// The batch is full: verify it right away on this goroutine
+		batch := b.take()
+		b.mu.Unlock()
+		b.verify(batch)
+	case len(b.pending) == 1:
+		// First proof of a new batch: start the time window
+		b.timer = time.AfterFunc(b.window, b.flush)
+		b.mu.Unlock()
+	default:
+		b.mu.Unlock()
+	}
+
+	return <-req.result
+}
+
+// flush verifies whatever is pending when the time window expires
+func (b *batchQueue) flush() {
+	b.mu.Lock()
+	batch := b.take()
+	b.mu.Unlock()
+	b.verify(batch)
+}
+
+// take removes the pending proofs from the queue. It must be called with `mu` held.
+func (b *batchQueue) take() []verifyRequest {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+func (b *batchQueue) verify(batch []verifyRequest) {
+	if len(batch) == 0 {
+		return
+	}
+
+	items := make([]cp_zkp.BatchItem, len(batch))
+	for i, req := range batch {
+		items[i] = req.item
+	}
+
+	verifier := &cp_zkp.Verifier{}
+	for i, valid := range verifier.VerifyBatch(items, b.params) {
+		batch[i].result <- valid
+	}
+}
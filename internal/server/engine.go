@@ -0,0 +1,321 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// Engine is a proof system accounts authenticate with. Every account records the protocol it
+// registered with, and the RPC handlers reach the matching engine by that name only, so a new
+// sigma protocol is added by registering an engine in `Config.Engines`.
+//
+// Public keys and commitments are pairs of group elements. Single-base protocols leave the
+// second element nil. A login passes the account's key and commitment first and, for a
+// two-factor account, the device key and commitment second; all of them answer the same `c`.
+//
+// Engines work on decoded values only: the v2 and v3 services parse their own wire format
+// with the checks of `ParseElement`/`DecodeElement` and leave the values that were not sent nil.
+type Engine interface {
+	// Protocol is the name clients register with, e.g. `cp_zkp.ProtocolSchnorr`
+	Protocol() string
+
+	// CheckPublicKey checks that the public values (y1, y2) of an account are the ones the
+	// protocol uses. `field` prefixes the names of the fields in validation errors.
+	CheckPublicKey(key cp_zkp.Statement, field string) error
+
+	// VerifyPossession checks the non-interactive proof, named `field` in the request, that the
+	// client holds the secret of `key`. The proof is bound to `context`.
+	VerifyPossession(key cp_zkp.Statement, proof Proof, context []byte, field string) (bool, error)
+
+	// CheckCommitment checks that the commitment (r1, r2) of a login holds the elements the
+	// protocol uses. `field` prefixes the names of the fields in validation errors.
+	CheckCommitment(commitment cp_zkp.Commitment, field string) error
+
+	// IssueChallenge derives the challenge `c` of a login, bound to `auth`, the keys and the
+	// commitments. The nonce is stored with the login and passed back to `Verify`.
+	IssueChallenge(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (c *big.Int, nonce []byte, err error)
+
+	// Verify reports whether `c` was issued for this login with `nonce`, and whether the
+	// answers `s` prove knowledge of the secrets of all the keys
+	Verify(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, s []*big.Int) bool
+}
+
+// GroupEngine is an engine whose accounts can also log in anonymously to a group: the client
+// proves that it holds the secret of one of the listed members' keys (an OR proof) without
+// revealing which one. Group logins go through the engine registered for `groupProtocol`,
+// which must implement this interface.
+type GroupEngine interface {
+	Engine
+
+	// IssueGroupChallenge derives the challenge of a group login, bound to `auth`, the keys of
+	// the listed members and one commitment per member. The nonce is stored with the login
+	// and passed back to `VerifyGroup`.
+	IssueGroupChallenge(auth GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (c *big.Int, nonce []byte, err error)
+
+	// VerifyGroup reports whether `c` was issued for this group login with `nonce`, and whether
+	// the responses, one per listed member, prove knowledge of the secret of one of the keys
+	VerifyGroup(auth GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, responses []GroupResponse) bool
+}
+
+// GroupAuthContext identifies the group login a challenge is issued for
+type GroupAuthContext struct {
+	ServerID string
+	Group    string
+	AuthID   string
+}
+
+// GroupResponse is the challenge share `c` and the answer `s` for one listed member of a group login
+type GroupResponse struct {
+	C, S *big.Int
+}
+
+// Proof is a non-interactive proof of possession as received in a request. Single-base
+// protocols send their commitment as `R1` and leave `R2` nil.
+type Proof struct {
+	R1, R2 cp_zkp.Element
+	C, S   *big.Int
+}
+
+// AuthContext identifies the login a challenge is issued for
+type AuthContext struct {
+	ServerID string
+	User     string
+	AuthID   string
+}
+
+// newEngines registers the built-in engines for the protocols `CPZKP` accepts, then the
+// engines of `Config.Engines`, which replace built-in engines of the same name
+func newEngines(config *Config, params *cp_zkp.CPZKPParams, batch *batchQueue) (map[string]Engine, error) {
+	engines := make(map[string]Engine)
+	for _, engine := range []Engine{
+		&chaumPedersenEngine{params: params, batch: batch},
+		&schnorrEngine{params: params},
+	} {
+		if config.CPZKP.SupportsProtocol(engine.Protocol()) {
+			engines[engine.Protocol()] = engine
+		}
+	}
+
+	for _, engine := range config.Engines {
+		if engine.Protocol() == "" {
+			return nil, errors.New("engine with an empty protocol name")
+		}
+		engines[engine.Protocol()] = engine
+	}
+	return engines, nil
+}
+
+// engine returns the engine of the named protocol. The empty name is Chaum-Pedersen.
+func (s *grpcServer) engine(protocol string) (Engine, error) {
+	if protocol == "" {
+		protocol = cp_zkp.ProtocolChaumPedersen
+	}
+
+	engine, ok := s.engines[protocol]
+	if !ok {
+		if _, err := cp_zkp.ParseProtocol(protocol); err != nil {
+			return nil, grpc_err.ErrInvalidArgument{Field: "protocol", Reason: err.Error()}
+		}
+		return nil, grpc_err.ErrInvalidArgument{Field: "protocol", Reason: "not accepted by this server"}
+	}
+	return engine, nil
+}
+
+// chaumPedersenEngine proves log_g(y1) = log_h(y2). Logins of single-factor accounts go
+// through the batch queue when batching is enabled.
+type chaumPedersenEngine struct {
+	params *cp_zkp.CPZKPParams
+	batch  *batchQueue
+}
+
+func (e *chaumPedersenEngine) Protocol() string {
+	return cp_zkp.ProtocolChaumPedersen
+}
+
+func (e *chaumPedersenEngine) CheckPublicKey(key cp_zkp.Statement, field string) error {
+	return checkPair(key.Y1, key.Y2, field+"y1", field+"y2")
+}
+
+func (e *chaumPedersenEngine) VerifyPossession(key cp_zkp.Statement, proof Proof, context []byte, field string) (bool, error) {
+	if err := checkPair(proof.R1, proof.R2, field+".r1", field+".r2"); err != nil {
+		return false, err
+	}
+
+	if err := checkScalars(proof, field); err != nil {
+		return false, err
+	}
+
+	verifier := &cp_zkp.Verifier{}
+	niProof := &cp_zkp.Proof{R1: proof.R1, R2: proof.R2, C: proof.C, S: proof.S}
+	return verifier.VerifyNIProof(key.Y1, key.Y2, niProof, context, e.params), nil
+}
+
+func (e *chaumPedersenEngine) CheckCommitment(commitment cp_zkp.Commitment, field string) error {
+	return checkPair(commitment.R1, commitment.R2, field+"r1", field+"r2")
+}
+
+func (e *chaumPedersenEngine) IssueChallenge(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (*big.Int, []byte, error) {
+	verifier := &cp_zkp.Verifier{}
+	return verifier.CreateContextChallenge(e.params, e.transcript(auth, keys, commitments))
+}
+
+func (e *chaumPedersenEngine) Verify(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, s []*big.Int) bool {
+	verifier := &cp_zkp.Verifier{}
+
+	// The stored challenge must belong to this server, user, auth_id and commitments
+	if !verifier.VerifyContextChallenge(e.params, e.transcript(auth, keys, commitments), nonce, c) {
+		return false
+	}
+
+	// Two-factor accounts answer the same challenge for the password-derived and the
+	// device-held secret, and both answers are verified together
+	if len(keys) > 1 {
+		return verifier.VerifyANDProof(e.params, keys, commitments, c, s)
+	}
+
+	// With batching enabled, the proof is verified together with the other proofs
+	// received within the same time window
+	y1, y2, r1, r2 := keys[0].Y1, keys[0].Y2, commitments[0].R1, commitments[0].R2
+	if e.batch != nil {
+		return e.batch.Verify(cp_zkp.BatchItem{Y1: y1, Y2: y2, R1: r1, R2: r2, C: c, S: s[0]})
+	}
+	return verifier.VerifyProof(y1, y2, r1, r2, c, s[0], e.params)
+}
+
+func (e *chaumPedersenEngine) IssueGroupChallenge(auth GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (*big.Int, []byte, error) {
+	verifier := &cp_zkp.Verifier{}
+	return verifier.CreateContextChallenge(e.params, e.groupTranscript(auth, keys, commitments))
+}
+
+// VerifyGroup checks the OR proof of a group login with `VerifyORProof`, outside the batch queue
+func (e *chaumPedersenEngine) VerifyGroup(auth GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, responses []GroupResponse) bool {
+	verifier := &cp_zkp.Verifier{}
+
+	// The stored challenge must belong to this server, group, auth_id, members and commitments
+	if !verifier.VerifyContextChallenge(e.params, e.groupTranscript(auth, keys, commitments), nonce, c) {
+		return false
+	}
+
+	transcripts := make([]*cp_zkp.ProofTranscript, len(responses))
+	for i, response := range responses {
+		transcripts[i] = &cp_zkp.ProofTranscript{R1: commitments[i].R1, R2: commitments[i].R2, C: response.C, S: response.S}
+	}
+	return verifier.VerifyORProof(e.params, keys, transcripts, c)
+}
+
+// groupTranscript returns the transcript the challenge of a group login is bound to
+func (e *chaumPedersenEngine) groupTranscript(auth GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) *cp_zkp.Transcript {
+	return cp_zkp.NewGroupAuthTranscript(e.params, auth.ServerID, auth.Group, auth.AuthID, keys, commitments)
+}
+
+// transcript returns the transcript the challenge of a login is bound to. For two-factor
+// accounts it also binds the device key and the device commitment.
+func (e *chaumPedersenEngine) transcript(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) *cp_zkp.Transcript {
+	t := cp_zkp.NewAuthTranscript(e.params, auth.ServerID, auth.User, auth.AuthID, keys[0].Y1, keys[0].Y2, commitments[0].R1, commitments[0].R2)
+	for i := 1; i < len(keys); i++ {
+		t.AppendElements("device", keys[i].Y1, keys[i].Y2, commitments[i].R1, commitments[i].R2)
+	}
+	return t
+}
+
+// schnorrEngine proves knowledge of log_g(y1) over the single base `g`
+type schnorrEngine struct {
+	params *cp_zkp.CPZKPParams
+}
+
+func (e *schnorrEngine) Protocol() string {
+	return cp_zkp.ProtocolSchnorr
+}
+
+func (e *schnorrEngine) CheckPublicKey(key cp_zkp.Statement, field string) error {
+	return checkSingle(key.Y1, key.Y2, field+"y1", field+"y2")
+}
+
+// VerifyPossession checks a Schnorr proof, whose commitment is sent as `r1`
+func (e *schnorrEngine) VerifyPossession(key cp_zkp.Statement, proof Proof, context []byte, field string) (bool, error) {
+	if err := checkSingle(proof.R1, proof.R2, field+".r1", field+".r2"); err != nil {
+		return false, err
+	}
+
+	if err := checkScalars(proof, field); err != nil {
+		return false, err
+	}
+
+	verifier := &cp_zkp.Verifier{}
+	schnorrProof := &cp_zkp.SchnorrProof{R: proof.R1, C: proof.C, S: proof.S}
+	return verifier.VerifySchnorrNIProof(key.Y1, schnorrProof, context, e.params), nil
+}
+
+func (e *schnorrEngine) CheckCommitment(commitment cp_zkp.Commitment, field string) error {
+	return checkSingle(commitment.R1, commitment.R2, field+"r1", field+"r2")
+}
+
+func (e *schnorrEngine) IssueChallenge(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (*big.Int, []byte, error) {
+	verifier := &cp_zkp.Verifier{}
+	return verifier.CreateContextChallenge(e.params, e.transcript(auth, keys, commitments))
+}
+
+// Verify checks every key with `VerifySchnorrProof`, outside the batch queue
+func (e *schnorrEngine) Verify(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, s []*big.Int) bool {
+	verifier := &cp_zkp.Verifier{}
+	if !verifier.VerifyContextChallenge(e.params, e.transcript(auth, keys, commitments), nonce, c) {
+		return false
+	}
+
+	valid := true
+	for i := range keys {
+		valid = verifier.VerifySchnorrProof(keys[i].Y1, commitments[i].R1, c, s[i], e.params) && valid
+	}
+	return valid
+}
+
+func (e *schnorrEngine) transcript(auth AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) *cp_zkp.Transcript {
+	t := cp_zkp.NewSchnorrAuthTranscript(e.params, auth.ServerID, auth.User, auth.AuthID, keys[0].Y1, commitments[0].R1)
+	for i := 1; i < len(keys); i++ {
+		t.AppendElements("device", keys[i].Y1, commitments[i].R1)
+	}
+	return t
+}
+
+// checkPair checks that both elements of a Chaum-Pedersen key or commitment were sent
+func checkPair(a, b cp_zkp.Element, fieldA, fieldB string) error {
+	if a == nil {
+		return missing(fieldA)
+	}
+	if b == nil {
+		return missing(fieldB)
+	}
+	return nil
+}
+
+// checkSingle checks the element of a single-base key or commitment. The `h`-side
+// field must be empty.
+func checkSingle(a, b cp_zkp.Element, fieldA, fieldB string) error {
+	if a == nil {
+		return missing(fieldA)
+	}
+	if b != nil {
+		return grpc_err.ErrInvalidArgument{Field: fieldB, Reason: fmt.Sprintf("not used by the %s protocol", cp_zkp.ProtocolSchnorr)}
+	}
+	return nil
+}
+
+// checkScalars checks that the challenge and the answer of a proof were sent
+func checkScalars(proof Proof, field string) error {
+	if proof.C == nil {
+		return missing(field + ".c")
+	}
+	if proof.S == nil {
+		return missing(field + ".s")
+	}
+	return nil
+}
+
+// missing reports a required field that was not sent
+func missing(field string) error {
+	return grpc_err.ErrInvalidArgument{Field: field, Reason: "missing"}
+}
@@ -0,0 +1,359 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/google/uuid"
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+type GroupAuthParams struct {
+	group       string
+	statements  []cp_zkp.Statement
+	commitments []cp_zkp.Commitment
+	c           *big.Int
+
+	// fresh randomness mixed into the derivation of `c`
+	nonce []byte
+}
+
+// Smallest anonymity set of a group login when `Config.MinGroupSize` is zero
+const defaultMinGroupSize = 2
+
+// groupProtocol is the protocol of the accounts that can be listed in a group login. Its
+// engine must be a `GroupEngine`.
+const groupProtocol = cp_zkp.ProtocolChaumPedersen
+
+// isMember reports whether `user` is listed in the configured members of `group`
+func (s *grpcServer) isMember(group, user string) bool {
+	for _, member := range s.Config.Groups[group] {
+		if member == user {
+			return true
+		}
+	}
+	return false
+}
+
+// eligible reports whether the account can be listed in a group login: it must not be
+// flagged, and it must use `groupProtocol` (e.g. Schnorr has no `y2`)
+func (r RegParams) eligible() bool {
+	return !r.compromised && r.protocol == groupProtocol
+}
+
+// groupEngine returns the engine group logins go through
+func (s *grpcServer) groupEngine() (GroupEngine, error) {
+	engine, err := s.engine(groupProtocol)
+	if err != nil {
+		return nil, err
+	}
+
+	groupEngine, ok := engine.(GroupEngine)
+	if !ok {
+		return nil, fmt.Errorf("the %s engine does not support group logins", groupProtocol)
+	}
+	return groupEngine, nil
+}
+
+// eligibleMembers returns the configured members of `group` that are registered and eligible.
+// The caller must hold `s.mu`.
+func (s *grpcServer) eligibleMembers(group string) []string {
+	var eligible []string
+	for _, user := range s.Config.Groups[group] {
+		if regParams, userExists := s.RegDir[user]; userExists && regParams.eligible() {
+			eligible = append(eligible, user)
+		}
+	}
+	return eligible
+}
+
+// minGroupSize returns the smallest anonymity set a group login is accepted with
+func (s *grpcServer) minGroupSize() int {
+	if s.Config.MinGroupSize > 0 {
+		return s.Config.MinGroupSize
+	}
+	return defaultMinGroupSize
+}
+
+// groupMember is a registered member of a group as listed by `GetGroup`
+type groupMember struct {
+	user string
+	key  cp_zkp.Statement
+	kdf  *api.KDFParams
+}
+
+// GetGroup: lists the registered members of a group with their public values (y1, y2) and
+// KDF params. A client needs the public values of the other members to build its proof,
+// and derives its own secret with its KDF params before committing.
+func (s *grpcServer) GetGroup(ctx context.Context, req *api.GroupRequest) (*api.GroupResponse, error) {
+	members, err := s.groupMembers(req.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api.GroupResponse{}
+	for _, member := range members {
+		res.Members = append(res.Members, &api.GroupMember{
+			User: member.user,
+			Y1:   member.key.Y1.String(),
+			Y2:   member.key.Y2.String(),
+			Kdf:  member.kdf,
+		})
+	}
+	return res, nil
+}
+
+// groupMembers returns the members of `group` that `GetGroup` lists
+func (s *grpcServer) groupMembers(group string) ([]groupMember, error) {
+	members, groupExists := s.Config.Groups[group]
+	if !groupExists {
+		return nil, fmt.Errorf("group %s does not exist on the server", group)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Members that are not registered yet, whose account is flagged or who registered with
+	// another protocol than Chaum-Pedersen (e.g. Schnorr, which has no `y2`) are left out
+	var listed []groupMember
+	for _, user := range members {
+		regParams, userExists := s.RegDir[user]
+		if !userExists || !regParams.eligible() {
+			continue
+		}
+
+		listed = append(listed, groupMember{
+			user: user,
+			key:  cp_zkp.Statement{Y1: regParams.y1, Y2: regParams.y2},
+			kdf:  regParams.kdf,
+		})
+	}
+	return listed, nil
+}
+
+// groupCommitment is a `CreateGroupAuthenticationChallenge` request with its values decoded
+// from either wire format. Values that were not sent are nil.
+type groupCommitment struct {
+	group       string
+	users       []string
+	commitments []cp_zkp.Commitment
+}
+
+// CreateGroupAuthenticationChallenge: commitment and challenge steps of an anonymous group
+// login. The client lists the members of the group and sends one commitment per member; it
+// proves later that it knows the secret of one of them without revealing which one.
+// The list must hold every eligible member that `GetGroup` returns, and at least
+// `Config.MinGroupSize` of them: a smaller list would narrow down who logged in.
+func (s *grpcServer) CreateGroupAuthenticationChallenge(ctx context.Context, req *api.GroupAuthenticationChallengeRequest) (
+	*api.GroupAuthenticationChallengeResponse, error) {
+
+	d := s.decoder()
+	login := groupCommitment{group: req.Group, users: req.Users}
+	for i, commitment := range req.Commitments {
+		login.commitments = append(login.commitments, cp_zkp.Commitment{
+			R1: d.element(commitment.R1, fmt.Sprintf("commitments[%d].r1", i)),
+			R2: d.element(commitment.R2, fmt.Sprintf("commitments[%d].r2", i)),
+		})
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	authID, c, err := s.createGroupAuthenticationChallenge(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GroupAuthenticationChallengeResponse{
+		AuthId: authID,
+		C:      c.String(),
+	}, nil
+}
+
+// createGroupAuthenticationChallenge issues the challenge of a group login and returns it
+// with its `auth_id`, whichever service received it
+func (s *grpcServer) createGroupAuthenticationChallenge(login groupCommitment) (string, *big.Int, error) {
+
+	if _, groupExists := s.Config.Groups[login.group]; !groupExists {
+		return "", nil, fmt.Errorf("group %s does not exist on the server", login.group)
+	}
+
+	if len(login.users) == 0 {
+		return "", nil, grpc_err.ErrInvalidArgument{Field: "users", Reason: "no members listed"}
+	}
+
+	if len(login.commitments) != len(login.users) {
+		return "", nil, grpc_err.ErrInvalidArgument{Field: "commitments", Reason: "expected one commitment per listed member"}
+	}
+
+	engine, err := s.groupEngine()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Look up the registered (y1, y2) of every listed member
+	statements := make([]cp_zkp.Statement, len(login.users))
+	listed := make(map[string]bool, len(login.users))
+
+	s.mu.Lock()
+	for i, user := range login.users {
+		regParams, userExists := s.RegDir[user]
+		switch {
+		case listed[user]:
+			s.mu.Unlock()
+			return "", nil, grpc_err.ErrInvalidArgument{Field: "users", Reason: fmt.Sprintf("member %s listed twice", user)}
+		case !s.isMember(login.group, user):
+			s.mu.Unlock()
+			return "", nil, fmt.Errorf("user %s is not a member of group %s", user, login.group)
+		case !userExists:
+			s.mu.Unlock()
+			return "", nil, fmt.Errorf("user %s is not registered on the server", user)
+		case regParams.compromised:
+			s.mu.Unlock()
+			return "", nil, grpc_err.ErrAccountCompromised{User: user, Reason: "login disabled"}
+		case regParams.protocol != groupProtocol:
+			s.mu.Unlock()
+			return "", nil, grpc_err.ErrInvalidArgument{Field: "users", Reason: fmt.Sprintf("member %s uses the %s protocol", user, regParams.protocol)}
+		}
+
+		listed[user] = true
+		statements[i] = cp_zkp.Statement{Y1: regParams.y1, Y2: regParams.y2}
+	}
+
+	// Every listed member is eligible and listed once, so the whole set is listed if the counts match
+	eligible := s.eligibleMembers(login.group)
+	s.mu.Unlock()
+
+	if len(eligible) < s.minGroupSize() {
+		return "", nil, fmt.Errorf("group %s has %d eligible members, anonymous logins need at least %d", login.group, len(eligible), s.minGroupSize())
+	}
+
+	if len(listed) != len(eligible) {
+		for _, user := range eligible {
+			if !listed[user] {
+				return "", nil, grpc_err.ErrInvalidArgument{Field: "users", Reason: fmt.Sprintf("member %s is not listed, every eligible member must be", user)}
+			}
+		}
+	}
+
+	for i, commitment := range login.commitments {
+		if err := engine.CheckCommitment(commitment, fmt.Sprintf("commitments[%d].", i)); err != nil {
+			return "", nil, err
+		}
+	}
+
+	authID, err := uuid.NewRandom()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Bind the challenge to this server, the group, the auth_id, the members and the commitments
+	auth_id := authID.String()
+	c, nonce, err := engine.IssueGroupChallenge(s.groupAuthContext(login.group, auth_id), statements, login.commitments)
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.mu.Lock()
+	s.GroupAuthDir[auth_id] = GroupAuthParams{
+		group:       login.group,
+		statements:  statements,
+		commitments: login.commitments,
+		c:           c,
+		nonce:       nonce,
+	}
+	s.mu.Unlock()
+
+	return auth_id, c, nil
+}
+
+// groupAnswer is a `VerifyGroupAuthentication` request with its values decoded from either
+// wire format. Values that were not sent are nil.
+type groupAnswer struct {
+	authID    string
+	responses []GroupResponse
+}
+
+// VerifyGroupAuthentication: response step of an anonymous group login. The OR proof is
+// verified against the listed members and, if valid, an anonymous session is issued for
+// the group. Each challenge can be answered only once.
+func (s *grpcServer) VerifyGroupAuthentication(ctx context.Context, req *api.GroupAuthenticationAnswerRequest) (
+	*api.GroupAuthenticationAnswerResponse, error) {
+
+	d := s.decoder()
+	answer := groupAnswer{authID: req.AuthId}
+	for i, response := range req.Responses {
+		answer.responses = append(answer.responses, GroupResponse{
+			C: d.scalar(response.C, fmt.Sprintf("responses[%d].c", i)),
+			S: d.scalar(response.S, fmt.Sprintf("responses[%d].s", i)),
+		})
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	sessionID, group, err := s.verifyGroupAuthentication(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.GroupAuthenticationAnswerResponse{
+		SessionId: sessionID,
+		Group:     group,
+	}, nil
+}
+
+// verifyGroupAuthentication verifies the OR proof of a group login and returns the anonymous
+// session with its group, whichever service received the answer
+func (s *grpcServer) verifyGroupAuthentication(answer groupAnswer) (string, string, error) {
+
+	s.mu.Lock()
+	authParams, idExists := s.GroupAuthDir[answer.authID]
+	delete(s.GroupAuthDir, answer.authID)
+	s.mu.Unlock()
+	if !idExists {
+		return "", "", fmt.Errorf("invalid authentication id: %s specified", answer.authID)
+	}
+
+	engine, err := s.groupEngine()
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(answer.responses) != len(authParams.statements) {
+		return "", "", grpc_err.ErrInvalidArgument{Field: "responses", Reason: "expected one response per listed member"}
+	}
+
+	for i, response := range answer.responses {
+		if response.C == nil {
+			return "", "", missing(fmt.Sprintf("responses[%d].c", i))
+		}
+
+		if response.S == nil {
+			return "", "", missing(fmt.Sprintf("responses[%d].s", i))
+		}
+	}
+
+	auth := s.groupAuthContext(authParams.group, answer.authID)
+	if !engine.VerifyGroup(auth, authParams.statements, authParams.commitments, authParams.c, authParams.nonce, answer.responses) {
+		return "", "", grpc_err.ErrInvalidGroupProof{Group: authParams.group}
+	}
+
+	// The session is bound to the group only: the server does not learn which member logged in
+	sessionID, err := newSessionID()
+	if err != nil {
+		return "", "", err
+	}
+
+	log.Printf("[grpcServer-Verifier]: Issued an anonymous session for group %s", authParams.group)
+	return sessionID, authParams.group, nil
+}
+
+// groupAuthContext identifies the group login `authID` to `group` on this server
+func (s *grpcServer) groupAuthContext(group, authID string) GroupAuthContext {
+	return GroupAuthContext{ServerID: s.serverID(), Group: group, AuthID: authID}
+}
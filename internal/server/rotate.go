@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// credentialRotation is a `RotateCredential` request with its values decoded from either
+// wire format. Values that were not sent are nil.
+type credentialRotation struct {
+	answer loginAnswer
+	key    cp_zkp.Statement
+	kdf    *api.KDFParams
+	proof  *Proof
+}
+
+// RotateCredential: replaces the public values (y1, y2) and KDF params of an account.
+// The client first runs `CreateAuthenticationChallenge` with its current secret `x`, then
+// answers the challenge `c` here and submits the new public values (y1', y2') with a proof
+// of possession of the new secret bound to the same `auth_id` and `c`. The registration
+// is swapped atomically and every pending login of the user is invalidated.
+// The protocol of the account and the device key of a two-factor account are kept.
+func (s *grpcServer) RotateCredential(ctx context.Context, req *api.RotateCredentialRequest) (
+	*api.RotateCredentialResponse, error) {
+
+	d := s.decoder()
+	rotation := credentialRotation{
+		answer: loginAnswer{
+			authID:  req.AuthId,
+			s:       d.scalar(req.S, "s"),
+			deviceS: d.scalar(req.DeviceS, "device_s"),
+		},
+		key:   cp_zkp.Statement{Y1: d.element(req.Y1, "y1"), Y2: d.element(req.Y2, "y2")},
+		kdf:   req.Kdf,
+		proof: d.proof(req.Proof, "proof"),
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if err := s.rotateCredential(rotation); err != nil {
+		return nil, err
+	}
+	return &api.RotateCredentialResponse{}, nil
+}
+
+// rotateCredential swaps the registration of an account, whichever service received the rotation
+func (s *grpcServer) rotateCredential(rotation credentialRotation) error {
+	authID := rotation.answer.authID
+	authParams, regParams, err := s.takeAuth(authID)
+	if err != nil {
+		return err
+	}
+	user := authParams.user
+
+	// The client must prove knowledge of the current secret
+	if err := s.verifyAnswer(regParams, authParams, rotation.answer); err != nil {
+		return err
+	}
+
+	if err := s.acceptAnswer(authID, authParams); err != nil {
+		return err
+	}
+
+	engine, err := s.engine(regParams.protocol)
+	if err != nil {
+		return err
+	}
+
+	// The account keeps its protocol
+	key := rotation.key
+	if err := engine.CheckPublicKey(key, ""); err != nil {
+		return err
+	}
+
+	// ... and of the new secret, in a proof bound to the challenge it just answered
+	if rotation.proof == nil {
+		return grpc_err.ErrInvalidCredentialRotation{User: user, Reason: "missing proof"}
+	}
+
+	valid, err := engine.VerifyPossession(key, *rotation.proof, cp_zkp.RotationContext(user, authID, authParams.c), "proof")
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return grpc_err.ErrInvalidCredentialRotation{User: user, Reason: possessionFailure(key)}
+	}
+
+	if rotation.kdf != nil {
+		if err := kdfFromProto(rotation.kdf).Validate(); err != nil {
+			return grpc_err.ErrInvalidKDFParams{User: user, Reason: err.Error()}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The challenge was consumed by `takeAuth`, so only one rotation per challenge can get
+	// here. A concurrent rotation of the same account through another challenge, or a
+	// commitment reuse flagged meanwhile, changes the registration and fails this one.
+	current := s.RegDir[user]
+	if current.compromised || !s.params.Group().Equal(current.y1, regParams.y1) {
+		return grpc_err.ErrInvalidChallengeResponse{S: rotation.answer.s.String()}
+	}
+
+	s.RegDir[user] = RegParams{
+		protocol: regParams.protocol,
+		y1:       key.Y1,
+		y2:       key.Y2,
+		kdf:      rotation.kdf,
+		device:   regParams.device,
+	}
+
+	// Challenges issued for the old credential must not be answered anymore
+	for pendingID, pending := range s.AuthDir {
+		if pending.user == user {
+			delete(s.AuthDir, pendingID)
+		}
+	}
+
+	log.Printf("[grpcServer-Verifier]: Rotated the credential of user %s", user)
+	return nil
+}
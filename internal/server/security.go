@@ -0,0 +1,209 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// Number of recent commitments remembered per user when `Config.CommitmentHistory` is zero.
+// A reuse is only detected within this window.
+const defaultCommitmentHistory = 256
+
+// Kinds of security events raised by the server
+const (
+	// EventCommitmentReuse: a client answered the challenges of two sessions with the same
+	// commitment (r1, r2). Anyone holding both transcripts can recover `x` from them
+	// (see `cp_zkp.ExtractSecret`).
+	EventCommitmentReuse = "commitment_reuse"
+
+	// EventCommitmentReplay: a commitment the user was recently challenged on was sent again.
+	// The new session is refused and the account is not flagged, since anyone who saw the
+	// commitment can resend it, but the replay may be an attack in progress.
+	EventCommitmentReplay = "commitment_replay"
+)
+
+// SecurityEvent reports a client behaviour that puts the account at risk
+type SecurityEvent struct {
+	Kind string
+	User string
+
+	// AuthIDs are the authentication sessions involved, oldest first
+	AuthIDs []string
+
+	Time time.Time
+}
+
+// commitmentLog remembers the most recent commitments of one user
+type commitmentLog struct {
+	// seen maps a commitment to the `auth_id` it was recorded in
+	seen map[string]string
+
+	// order is a ring buffer of the remembered commitments, used to evict the oldest
+	order []string
+	next  int
+	size  int
+}
+
+// commitmentKey identifies the commitment (r1, r2) by a hash of its canonical encoding.
+// `r2` is nil for Schnorr accounts.
+func commitmentKey(r1, r2 cp_zkp.Element) string {
+	h := sha256.New()
+	h.Write(r1.Bytes())
+	if r2 != nil {
+		h.Write(r2.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// record remembers the commitment for `authID`. If it was already seen, nothing is
+// recorded and the `auth_id` of the earlier session is returned.
+func (l *commitmentLog) record(key, authID string) (string, bool) {
+	if earlier, ok := l.seen[key]; ok {
+		return earlier, true
+	}
+
+	if len(l.order) < l.size {
+		l.order = append(l.order, key)
+	} else {
+		delete(l.seen, l.order[l.next])
+		l.order[l.next] = key
+		l.next = (l.next + 1) % l.size
+	}
+	l.seen[key] = authID
+	return "", false
+}
+
+// commitmentLog returns the log of `user` in `logs`, created on first use.
+// The caller must hold `s.mu`.
+func (s *grpcServer) commitmentLog(logs map[string]*commitmentLog, user string) *commitmentLog {
+	l, ok := logs[user]
+	if !ok {
+		size := s.Config.CommitmentHistory
+		if size <= 0 {
+			size = defaultCommitmentHistory
+		}
+		l = &commitmentLog{seen: make(map[string]string), size: size}
+		logs[user] = l
+	}
+	return l
+}
+
+// recordChallenged checks the commitments of a new session of `user` against the ones the
+// user was recently challenged on, and records them. It reports the field of the first
+// reused commitment with the event to raise, or "" and nil if none was reused. Anyone can
+// resend a commitment they have seen, so a reuse is only refused: it does not flag the account.
+// The caller must hold `s.mu`.
+func (s *grpcServer) recordChallenged(user, authID string, authParams AuthParams) (string, *SecurityEvent) {
+	l := s.commitmentLog(s.challenged, user)
+
+	fields := []string{"r1", "device.r1"}
+	keys := make([]string, 0, 2)
+	for i, commitment := range authParams.commitments() {
+		key := commitmentKey(commitment.R1, commitment.R2)
+		if earlier, reused := l.seen[key]; reused {
+			return fields[i], &SecurityEvent{
+				Kind:    EventCommitmentReplay,
+				User:    user,
+				AuthIDs: []string{earlier, authID},
+				Time:    time.Now(),
+			}
+		}
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		l.record(key, authID)
+	}
+	return "", nil
+}
+
+// recordVerified records the commitments of the session `authID` of `user`, whose answer
+// was just verified. If another verified session used one of them, the user did answer two
+// challenges for the same commitment: only the holder of `x` can do that, and `x` may have
+// leaked. The account is then flagged as compromised and the event is returned.
+// The caller must hold `s.mu`.
+func (s *grpcServer) recordVerified(user, authID string, authParams AuthParams) *SecurityEvent {
+	l := s.commitmentLog(s.verified, user)
+
+	for _, commitment := range authParams.commitments() {
+		earlier, reused := l.record(commitmentKey(commitment.R1, commitment.R2), authID)
+		if !reused {
+			continue
+		}
+
+		regParams := s.RegDir[user]
+		regParams.compromised = true
+		s.RegDir[user] = regParams
+
+		return &SecurityEvent{
+			Kind:    EventCommitmentReuse,
+			User:    user,
+			AuthIDs: []string{earlier, authID},
+			Time:    time.Now(),
+		}
+	}
+	return nil
+}
+
+// acceptAnswer records the commitments of the verified login `authID`, and refuses it if
+// it reused the commitment of another verified login
+func (s *grpcServer) acceptAnswer(authID string, authParams AuthParams) error {
+	s.mu.Lock()
+	event := s.recordVerified(authParams.user, authID, authParams)
+	s.mu.Unlock()
+
+	if event != nil {
+		s.raiseSecurityEvent(event)
+		return grpc_err.ErrAccountCompromised{User: authParams.user, Reason: "proof commitment (r1, r2) reused"}
+	}
+	return nil
+}
+
+// Admin runs the operator actions on the accounts of a server
+type Admin struct {
+	srv *grpcServer
+}
+
+// ClearCompromised lifts the compromised flag of `user` and forgets the user's recorded
+// commitments, so that the account can log in again. Clear it only once the user was
+// verified out of band, and have the user rotate the credential right away: whoever holds
+// the two transcripts that raised the flag may know `x`.
+func (a *Admin) ClearCompromised(user string) error {
+	s := a.srv
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	regParams, userExists := s.RegDir[user]
+	if !userExists {
+		return fmt.Errorf("user %s is not registered on the server", user)
+	}
+
+	regParams.compromised = false
+	s.RegDir[user] = regParams
+	delete(s.challenged, user)
+	delete(s.verified, user)
+
+	log.Printf("[grpcServer-Security]: Cleared the compromised flag of user %s", user)
+	return nil
+}
+
+// raiseSecurityEvent logs the event and hands it to the configured handler, if any.
+// The caller must not hold `s.mu`, so that the handler may call back into the server.
+func (s *grpcServer) raiseSecurityEvent(event *SecurityEvent) {
+	outcome := "session refused"
+	if event.Kind == EventCommitmentReuse {
+		outcome = "account flagged as compromised"
+	}
+	log.Printf("[grpcServer-Security]: %s for user %s in sessions %v, %s",
+		event.Kind, event.User, event.AuthIDs, outcome)
+
+	if s.Config.OnSecurityEvent != nil {
+		s.Config.OnSecurityEvent(*event)
+	}
+}
@@ -2,39 +2,100 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"net"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
 	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	api_v3 "github.com/srinathLN7/zkp_auth/api/v3/proto"
 	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
-	"github.com/srinathLN7/zkp_auth/lib/util"
+	"github.com/srinathLN7/zkp_auth/lib/config"
 	"google.golang.org/grpc"
 )
 
 type CPZKP interface {
 	InitCPZKPParams() (*cp_zkp.CPZKPParams, error)
+
+	// SupportsProtocol reports whether accounts may be registered with the named
+	// identification protocol (`cp_zkp.ProtocolChaumPedersen` or `cp_zkp.ProtocolSchnorr`)
+	SupportsProtocol(protocol string) bool
 }
 
 type Config struct {
 	CPZKP CPZKP
+
+	// ServerID identifies this server in every challenge it issues, so that a
+	// proof transcript captured here is meaningless on any other server
+	ServerID string
+
+	// BatchWindow enables batch verification: proofs received within this time
+	// window (or until BatchSize proofs are pending) are verified together.
+	// Zero verifies every proof on its own.
+	BatchWindow time.Duration
+	BatchSize   int
+
+	// OnSecurityEvent, if set, is called for every security event, e.g. to alert an
+	// operator. Events are logged in any case.
+	OnSecurityEvent func(SecurityEvent)
+
+	// CommitmentHistory is the number of recent commitments remembered per user to detect
+	// their reuse. Zero remembers 256.
+	CommitmentHistory int
+
+	// Groups maps the name of a group to its members. A member can log in to the group
+	// anonymously, proving that it holds the secret of one of the listed accounts.
+	Groups map[string][]string
+
+	// MinGroupSize is the smallest number of eligible members a group must have before its
+	// members can log in anonymously. Zero requires 2.
+	MinGroupSize int
+
+	// Engines registers additional proof systems by their protocol name. They replace the
+	// built-in Chaum-Pedersen and Schnorr engines of the same name, which run over the
+	// `CPZKP` params and are registered for every protocol `CPZKP.SupportsProtocol` accepts.
+	Engines []Engine
 }
 
 type RegParams struct {
-	y1 *big.Int
-	y2 *big.Int
+	// protocol names the engine of the account, chosen at registration.
+	// `y2` is nil for single-base protocols such as `cp_zkp.ProtocolSchnorr`.
+	protocol string
+
+	y1 cp_zkp.Element
+	y2 cp_zkp.Element
+
+	// kdf holds the salt and cost the client derived `x` with.
+	// It is returned to the client at login.
+	kdf *api.KDFParams
+
+	// device holds the public values of the device-held secret of a two-factor account.
+	// Logins must then prove both secrets with `cp_zkp.VerifyANDProof`.
+	device *cp_zkp.Statement
+
+	// compromised is set when the client misbehaved in a way that may have leaked `x`.
+	// No further login is accepted for the account until `Admin.ClearCompromised`.
+	compromised bool
 }
 
 type AuthParams struct {
 	user string
 	c    *big.Int
-	r1   *big.Int
-	r2   *big.Int
+	r1   cp_zkp.Element
+	r2   cp_zkp.Element // nil for Schnorr accounts
+
+	// device is the commitment for the device-held secret of a two-factor account
+	device *cp_zkp.Commitment
+
+	// fresh randomness mixed into the derivation of `c`
+	nonce []byte
 }
 
 type grpcServer struct {
@@ -50,6 +111,23 @@ type grpcServer struct {
 	// Limited by in-memory non-persistence storage
 	AuthDir map[string]AuthParams
 
+	// Pending anonymous group logins, by `auth_id`
+	GroupAuthDir map[string]GroupAuthParams
+
+	// challenged and verified remember the recent commitments (r1, r2) of every user that
+	// were challenged, and whose answer verified, to detect their reuse
+	challenged map[string]*commitmentLog
+	verified   map[string]*commitmentLog
+
+	// mu guards `RegDir`, `AuthDir`, `GroupAuthDir`, `challenged` and `verified` against concurrent RPCs
+	mu sync.Mutex
+
+	// params are the ZKP system params, built once when the server starts
+	params *cp_zkp.CPZKPParams
+
+	// engines are the proof systems accounts register with, by protocol name
+	engines map[string]Engine
+
 	*Config
 }
 
@@ -85,23 +163,81 @@ func RunServer(config *Config) {
 }
 
 func newgrpcServer(config *Config) (*grpcServer, error) {
+	// refuse to start with weak or malformed ZKP system params
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	if err != nil {
+		return nil, err
+	}
+
 	// initialize the server with ZKP system params and an empty user directory
-	return &grpcServer{
-		RegDir:  make(map[string]RegParams),
-		AuthDir: make(map[string]AuthParams),
-		Config:  config,
-	}, nil
+	srv := &grpcServer{
+		RegDir:       make(map[string]RegParams),
+		AuthDir:      make(map[string]AuthParams),
+		GroupAuthDir: make(map[string]GroupAuthParams),
+		challenged:   make(map[string]*commitmentLog),
+		verified:     make(map[string]*commitmentLog),
+		params:       cpzkpParams,
+		Config:       config,
+	}
+
+	// batch queues Chaum-Pedersen proofs for batch verification when `BatchWindow` is set
+	var batch *batchQueue
+	if config.BatchWindow > 0 {
+		batch = newBatchQueue(cpzkpParams, config.BatchWindow, config.BatchSize)
+	}
+
+	srv.engines, err = newEngines(config, cpzkpParams, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv, nil
 }
 
-// NewGRPCServer: creates a grpc server and registers the service to that server
+// NewGRPCServer: creates a grpc server and registers the v2 and v3 services to that server
 func NewGRPCSever(config *Config) (*grpc.Server, error) {
+	gsrv, _, err := NewGRPCServerWithAdmin(config)
+	return gsrv, err
+}
+
+// NewGRPCServerWithAdmin: creates a grpc server as `NewGRPCSever` does, and returns the
+// handle the operator manages its accounts with
+func NewGRPCServerWithAdmin(config *Config) (*grpc.Server, *Admin, error) {
 	gsrv := grpc.NewServer()
 	srv, err := newgrpcServer(config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	api.RegisterAuthServer(gsrv, srv)
-	return gsrv, nil
+	api_v3.RegisterAuthServer(gsrv, &authServerV3{v2: srv})
+	return gsrv, &Admin{srv: srv}, nil
+}
+
+// serverID returns the configured server identity or the default one
+func (s *grpcServer) serverID() string {
+	if s.Config.ServerID != "" {
+		return s.Config.ServerID
+	}
+	return config.SERVER_ID
+}
+
+// registration is a `Register` request with its values decoded from either wire format.
+// Values that were not sent are nil.
+type registration struct {
+	user     string
+	protocol string
+	key      cp_zkp.Statement
+	kdf      *api.KDFParams
+	proof    *Proof
+
+	// device is the optional device key of a two-factor account
+	device *deviceRegistration
+}
+
+// deviceRegistration is the device key of a registration with its proof of possession
+type deviceRegistration struct {
+	key   cp_zkp.Statement
+	proof *Proof
 }
 
 // Register: Simply registers a new grpc client (prover) on the server side
@@ -109,127 +245,449 @@ func NewGRPCSever(config *Config) (*grpc.Server, error) {
 func (s *grpcServer) Register(ctx context.Context, req *api.RegisterRequest) (
 	*api.RegisterResponse, error) {
 
+	d := s.decoder()
+	reg := registration{
+		user:     req.User,
+		protocol: req.Protocol,
+		key:      cp_zkp.Statement{Y1: d.element(req.Y1, "y1"), Y2: d.element(req.Y2, "y2")},
+		kdf:      req.Kdf,
+		proof:    d.proof(req.Proof, "proof"),
+	}
+	if req.Device != nil {
+		reg.device = &deviceRegistration{
+			key:   cp_zkp.Statement{Y1: d.element(req.Device.Y1, "device.y1"), Y2: d.element(req.Device.Y2, "device.y2")},
+			proof: d.proof(req.Device.Proof, "device.proof"),
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	if err := s.register(reg); err != nil {
+		return nil, err
+	}
+	return &api.RegisterResponse{}, nil
+}
+
+// register stores the public values of a new account, whichever service received them
+func (s *grpcServer) register(reg registration) error {
+
 	// ASSUMPTION: The `req.user` passed in for every user is UNIQUE
 	// Check if the user already exists
 
-	if _, userExists := s.RegDir[req.User]; userExists {
-		return nil, grpc_err.ErrInvalidRegistration{User: req.User}
+	// The account is served by the engine of its protocol from now on
+	engine, err := s.engine(reg.protocol)
+	if err != nil {
+		return err
 	}
 
-	Y1, err := util.ParseBigInt(req.Y1, "y1")
-	if err != nil {
-		return nil, err
+	if err := engine.CheckPublicKey(reg.key, ""); err != nil {
+		return err
+	}
+
+	// The client must prove that it holds the secret of the key, e.g. that y1 = g^x and
+	// y2 = h^x for the same `x` (Chaum-Pedersen), or that it knows x = log_g(y1) (Schnorr)
+	if err := s.verifyRegistrationProof(engine, reg.user, reg.proof, reg.key, "proof"); err != nil {
+		return err
+	}
+
+	// An optional device key turns the account into a two-factor account
+	if reg.device != nil && engine.Protocol() != cp_zkp.ProtocolChaumPedersen {
+		return grpc_err.ErrInvalidArgument{Field: "device", Reason: "two-factor accounts use the chaum-pedersen protocol"}
 	}
 
-	Y2, err := util.ParseBigInt(req.Y2, "y2")
+	device, err := s.checkDeviceKey(engine, reg)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	s.RegDir[req.User] = RegParams{
-		y1: Y1,
-		y2: Y2,
+	// Refuse salts and costs that the client could not safely derive `x` with at login
+	if reg.kdf != nil {
+		if err := kdfFromProto(reg.kdf).Validate(); err != nil {
+			return grpc_err.ErrInvalidKDFParams{User: reg.user, Reason: err.Error()}
+		}
 	}
 
-	return &api.RegisterResponse{}, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, userExists := s.RegDir[reg.user]; userExists {
+		return grpc_err.ErrInvalidRegistration{User: reg.user}
+	}
+
+	s.RegDir[reg.user] = RegParams{
+		protocol: engine.Protocol(),
+		y1:       reg.key.Y1,
+		y2:       reg.key.Y2,
+		kdf:      reg.kdf,
+		device:   device,
+	}
+
+	return nil
+}
+
+// loginCommitment is a `CreateAuthenticationChallenge` request with its values decoded from
+// either wire format. Values that were not sent are nil.
+type loginCommitment struct {
+	user       string
+	commitment cp_zkp.Commitment
+
+	// device is the commitment to the device-held secret of a two-factor account
+	device *cp_zkp.Commitment
+}
+
+// loginChallenge is the challenge issued for a login commitment
+type loginChallenge struct {
+	authID string
+	c      *big.Int
+	kdf    *api.KDFParams
 }
 
 func (s *grpcServer) CreateAuthenticationChallenge(ctx context.Context, req *api.AuthenticationChallengeRequest) (
 	*api.AuthenticationChallengeResponse, error) {
 
+	d := s.decoder()
+	login := loginCommitment{
+		user:       req.User,
+		commitment: cp_zkp.Commitment{R1: d.element(req.R1, "r1"), R2: d.element(req.R2, "r2")},
+	}
+	if req.Device != nil {
+		login.device = &cp_zkp.Commitment{R1: d.element(req.Device.R1, "device.r1"), R2: d.element(req.Device.R2, "device.r2")}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	challenge, err := s.createAuthenticationChallenge(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.AuthenticationChallengeResponse{
+		AuthId: challenge.authID,
+		C:      challenge.c.String(),
+		Kdf:    challenge.kdf,
+	}, nil
+}
+
+// createAuthenticationChallenge issues the challenge of a login, whichever service received it
+func (s *grpcServer) createAuthenticationChallenge(login loginCommitment) (loginChallenge, error) {
+
 	// First check if the user is registered on the server
 	// Otherwise throw an error before proceeding further
-	if _, userExists := s.RegDir[req.User]; !userExists {
-		return nil, fmt.Errorf("user %s is not registered on the server", req.User)
+	s.mu.Lock()
+	regParams, userExists := s.RegDir[login.user]
+	s.mu.Unlock()
+	if !userExists {
+		return loginChallenge{}, fmt.Errorf("user %s is not registered on the server", login.user)
 	}
 
-	cpzkpParams, err := s.Config.CPZKP.InitCPZKPParams()
+	if regParams.compromised {
+		return loginChallenge{}, grpc_err.ErrAccountCompromised{User: login.user, Reason: "login disabled"}
+	}
+
+	engine, err := s.engine(regParams.protocol)
 	if err != nil {
-		return nil, err
+		return loginChallenge{}, err
 	}
 
-	// Create a verifier to create the authentication challenge
-	verifier := &cp_zkp.Verifier{}
-	c, err := verifier.CreateProofChallenge(cpzkpParams)
+	// We use the google's widely used `uuid` pkg to generate the authID
+	authID, err := uuid.NewRandom()
 	if err != nil {
-		return nil, err
+		return loginChallenge{}, err
+	}
+
+	if err := engine.CheckCommitment(login.commitment, ""); err != nil {
+		return loginChallenge{}, err
+	}
+
+	authParams := AuthParams{user: login.user, r1: login.commitment.R1, r2: login.commitment.R2}
+
+	// Two-factor accounts commit to the device-held secret too
+	switch {
+	case regParams.device != nil && login.device == nil:
+		return loginChallenge{}, grpc_err.ErrInvalidArgument{Field: "device", Reason: "the account requires a device key commitment"}
+	case regParams.device == nil && login.device != nil:
+		return loginChallenge{}, grpc_err.ErrInvalidArgument{Field: "device", Reason: "the account has no device key"}
+	case login.device != nil:
+		if err := engine.CheckCommitment(*login.device, "device."); err != nil {
+			return loginChallenge{}, err
+		}
+		authParams.device = login.device
+	}
+
+	// Bind the challenge to this server, the user, the auth_id and the commitments
+	auth_id := authID.String()
+	c, nonce, err := engine.IssueChallenge(s.authContext(login.user, auth_id), regParams.keys(), authParams.commitments())
+	if err != nil {
+		return loginChallenge{}, err
+	}
+
+	// A commitment must never be challenged twice: answering two challenges for the
+	// same (r1, r2) reveals `x`. A reuse is refused and reported, but anyone can resend a
+	// commitment, so only a second verified answer flags the account (see `acceptAnswer`).
+	s.mu.Lock()
+	if field, event := s.recordChallenged(login.user, auth_id, authParams); event != nil {
+		s.mu.Unlock()
+		s.raiseSecurityEvent(event)
+		return loginChallenge{}, grpc_err.ErrInvalidArgument{Field: field, Reason: "the commitment was already challenged"}
 	}
 
-	// Store the generated random value `c` and the `auth_id` in the authentication directory
+	// Store the generated value `c` and the `auth_id` in the authentication directory
 	// for authentication verification process in the next step
+	authParams.c = c
+	authParams.nonce = nonce
+	s.AuthDir[auth_id] = authParams
+	s.mu.Unlock()
 
-	// We use the google's widely used `uuid` pkg to generate the authID
-	authID, err := uuid.NewRandom()
+	return loginChallenge{authID: auth_id, c: c, kdf: regParams.kdf}, nil
+}
+
+// verifyRegistrationProof checks the non-interactive proof of possession sent with a
+// registration for `key`. It is bound to the user name through `RegistrationContext`.
+// `field` names the proof in the request, e.g. "proof" or "device.proof".
+func (s *grpcServer) verifyRegistrationProof(engine Engine, user string, proof *Proof, key cp_zkp.Statement, field string) error {
+	if proof == nil {
+		return grpc_err.ErrInvalidRegistrationProof{User: user, Reason: "missing " + field}
+	}
+
+	valid, err := engine.VerifyPossession(key, *proof, cp_zkp.RegistrationContext(user), field)
 	if err != nil {
+		return err
+	}
+
+	if !valid {
+		reason := possessionFailure(key)
+		if field != "proof" {
+			reason = field + ": " + reason
+		}
+		return grpc_err.ErrInvalidRegistrationProof{User: user, Reason: reason}
+	}
+	return nil
+}
+
+// possessionFailure is the reason reported for an invalid proof of possession
+func possessionFailure(key cp_zkp.Statement) string {
+	if key.Y2 == nil {
+		return "invalid proof of knowledge of log_g(y1)"
+	}
+	return "y1 and y2 do not share the same exponent"
+}
+
+// checkDeviceKey checks the optional device key of a registration and its proof of possession
+func (s *grpcServer) checkDeviceKey(engine Engine, reg registration) (*cp_zkp.Statement, error) {
+	if reg.device == nil {
+		return nil, nil
+	}
+
+	if err := engine.CheckPublicKey(reg.device.key, "device."); err != nil {
 		return nil, err
 	}
 
-	R1, err := util.ParseBigInt(req.R1, "r1")
-	if err != nil {
+	if err := s.verifyRegistrationProof(engine, reg.user, reg.device.proof, reg.device.key, "device.proof"); err != nil {
 		return nil, err
 	}
+	return &reg.device.key, nil
+}
+
+// keys returns the public keys a login to the account proves: the account's key and,
+// for a two-factor account, the device key
+func (r RegParams) keys() []cp_zkp.Statement {
+	keys := []cp_zkp.Statement{{Y1: r.y1, Y2: r.y2}}
+	if r.device != nil {
+		keys = append(keys, *r.device)
+	}
+	return keys
+}
+
+// commitments returns the commitments of a login, in the order of `RegParams.keys`
+func (a AuthParams) commitments() []cp_zkp.Commitment {
+	commitments := []cp_zkp.Commitment{{R1: a.r1, R2: a.r2}}
+	if a.device != nil {
+		commitments = append(commitments, *a.device)
+	}
+	return commitments
+}
+
+// authContext identifies the login `authID` of `user` on this server
+func (s *grpcServer) authContext(user, authID string) AuthContext {
+	return AuthContext{ServerID: s.serverID(), User: user, AuthID: authID}
+}
+
+// decoder parses the decimal values of a v2 request
+func (s *grpcServer) decoder() *v2Decoder {
+	return &v2Decoder{params: s.params}
+}
 
-	R2, err := util.ParseBigInt(req.R2, "r2")
+// v2Decoder parses elements and scalars with `ParseElement` and `ParseScalar` and keeps the
+// first failure. Empty values are nil, for the handlers to reject when required.
+type v2Decoder struct {
+	params *cp_zkp.CPZKPParams
+	err    error
+}
+
+func (d *v2Decoder) element(str, field string) cp_zkp.Element {
+	if str == "" || d.err != nil {
+		return nil
+	}
+
+	e, err := d.params.ParseElement(str, field)
 	if err != nil {
-		return nil, err
+		d.err = invalidArgument(err)
+		return nil
 	}
+	return e
+}
 
-	auth_id := authID.String()
-	s.AuthDir[auth_id] = AuthParams{user: req.User,
-		c:  c,
-		r1: R1,
-		r2: R2,
+func (d *v2Decoder) scalar(str, field string) *big.Int {
+	if str == "" || d.err != nil {
+		return nil
 	}
 
-	return &api.AuthenticationChallengeResponse{
-		AuthId: auth_id,
-		C:      c.String(),
-	}, nil
+	v, err := d.params.ParseScalar(str, field)
+	if err != nil {
+		d.err = invalidArgument(err)
+		return nil
+	}
+	return v
+}
+
+// proof parses a non-interactive proof received over the wire. `field` names the proof in
+// the request and prefixes the names of its fields.
+func (d *v2Decoder) proof(proof *api.Proof, field string) *Proof {
+	if proof == nil {
+		return nil
+	}
+
+	return &Proof{
+		R1: d.element(proof.R1, field+".r1"),
+		R2: d.element(proof.R2, field+".r2"),
+		C:  d.scalar(proof.C, field+".c"),
+		S:  d.scalar(proof.S, field+".s"),
+	}
+}
+
+// invalidArgument converts the validation failure of a client-supplied field into an
+// `InvalidArgument` status naming the field
+func invalidArgument(err error) error {
+	var input cp_zkp.ErrInvalidInput
+	if errors.As(err, &input) {
+		return grpc_err.ErrInvalidArgument{Field: input.Field, Reason: input.Reason.Error()}
+	}
+	return err
+}
+
+// kdfFromProto converts the KDF params received from the client
+func kdfFromProto(kdf *api.KDFParams) *cp_zkp.KDFParams {
+	return &cp_zkp.KDFParams{
+		Algorithm:   kdf.Algorithm,
+		Salt:        kdf.Salt,
+		Time:        kdf.Time,
+		Memory:      kdf.Memory,
+		Parallelism: kdf.Parallelism,
+	}
 }
 
 func (s *grpcServer) VerifyAuthentication(ctx context.Context, req *api.AuthenticationAnswerRequest) (
 	*api.AuthenticationAnswerResponse, error) {
 
-	// First check if the authentication id passed is valid
-	if _, idExists := s.AuthDir[req.AuthId]; !idExists {
-		return nil, fmt.Errorf("invalid authentication id: %s specified", req.AuthId)
+	d := s.decoder()
+	answer := loginAnswer{
+		authID:  req.AuthId,
+		s:       d.scalar(req.S, "s"),
+		deviceS: d.scalar(req.DeviceS, "device_s"),
+	}
+	if d.err != nil {
+		return nil, d.err
 	}
 
-	// To verify the proof, we need the system params and
-	// y1, y2, r1,r2, c, s
-	cpzkpParams, err := s.Config.CPZKP.InitCPZKPParams()
+	sessionID, err := s.verifyAuthentication(answer)
 	if err != nil {
 		return nil, err
 	}
+	return &api.AuthenticationAnswerResponse{SessionId: sessionID}, nil
+}
 
-	// Get the user name and `c` from the current `auth_id`
-	user := s.AuthDir[req.AuthId].user
-	c := s.AuthDir[req.AuthId].c
+// loginAnswer is the answer to the challenge of the pending login `authID`, decoded from either
+// wire format. `deviceS` answers for the device-held secret of a two-factor account.
+type loginAnswer struct {
+	authID     string
+	s, deviceS *big.Int
+}
 
-	// Retrieve y1, y2, r1, r2
-	y1 := s.RegDir[user].y1
-	y2 := s.RegDir[user].y2
-	r1 := s.AuthDir[req.AuthId].r1
-	r2 := s.AuthDir[req.AuthId].r2
+// verifyAuthentication verifies the answer to a login challenge and returns the new session
+func (s *grpcServer) verifyAuthentication(answer loginAnswer) (string, error) {
 
-	// convert `req.S` to big.Int
-	S, err := util.ParseBigInt(req.S, "s")
+	// First check if the authentication id passed is valid. The pending login is consumed,
+	// so each challenge can be answered only once.
+	authParams, regParams, err := s.takeAuth(answer.authID)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	// Create a verifier to verify the challenge
-	verifier := &cp_zkp.Verifier{}
-	isValidProof := verifier.VerifyProof(y1, y2, r1, r2, c, S, cpzkpParams)
-	if !isValidProof {
-		return nil, grpc_err.ErrInvalidChallengeResponse{S: req.S}
+	if err := s.verifyAnswer(regParams, authParams, answer); err != nil {
+		return "", err
 	}
 
-	// If a valid proof is presented - then generate a sessionID and pass it as a response
-	sessionID, err := uuid.NewRandom()
+	if err := s.acceptAnswer(answer.authID, authParams); err != nil {
+		return "", err
+	}
+
+	return newSessionID()
+}
+
+// takeAuth removes the pending login `authID` and returns it with the registration of its user.
+// Every answer consumes the login, valid or not, so that a captured transcript cannot be replayed.
+func (s *grpcServer) takeAuth(authID string) (AuthParams, RegParams, error) {
+	s.mu.Lock()
+	authParams, idExists := s.AuthDir[authID]
+	delete(s.AuthDir, authID)
+	regParams := s.RegDir[authParams.user]
+	s.mu.Unlock()
+	if !idExists {
+		return AuthParams{}, RegParams{}, fmt.Errorf("invalid authentication id: %s specified", authID)
+	}
+
+	if regParams.compromised {
+		return AuthParams{}, RegParams{}, grpc_err.ErrAccountCompromised{User: authParams.user, Reason: "login disabled"}
+	}
+
+	return authParams, regParams, nil
+}
+
+// verifyAnswer checks the answer `s` (and `deviceS` for two-factor accounts) to the challenge
+// of the pending login with the engine of the account
+func (s *grpcServer) verifyAnswer(regParams RegParams, authParams AuthParams, answer loginAnswer) error {
+	engine, err := s.engine(regParams.protocol)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &api.AuthenticationAnswerResponse{SessionId: sessionID.String()}, nil
+	if answer.s == nil {
+		return missing("s")
+	}
+
+	responses := []*big.Int{answer.s}
+	if regParams.device != nil {
+		if answer.deviceS == nil {
+			return missing("device_s")
+		}
+		responses = append(responses, answer.deviceS)
+	}
+
+	auth := s.authContext(authParams.user, answer.authID)
+	if !engine.Verify(auth, regParams.keys(), authParams.commitments(), authParams.c, authParams.nonce, responses) {
+		return grpc_err.ErrInvalidChallengeResponse{S: answer.s.String()}
+	}
+
+	return nil
+}
+
+// newSessionID generates the sessionID returned for a valid proof
+func newSessionID() (string, error) {
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return sessionID.String(), nil
 }
@@ -0,0 +1,440 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	api_v3 "github.com/srinathLN7/zkp_auth/api/v3/proto"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+)
+
+// supportedVersions are the protocol versions served, oldest first
+var supportedVersions = []api_v3.Version{api_v3.Version_VERSION_2, api_v3.Version_VERSION_3}
+
+// authServerV3 serves the v3 schema of the `Auth` service. Every request is checked against
+// its header, its binary values are decoded strictly and it is handed to the handler the v2
+// service decodes its decimal values for, so both versions share the same accounts, pending
+// logins and engines.
+type authServerV3 struct {
+	api_v3.UnimplementedAuthServer
+
+	v2 *grpcServer
+}
+
+// GetVersions: lists the versions, the group and the protocols the server accepts
+func (s *authServerV3) GetVersions(ctx context.Context, req *api_v3.VersionsRequest) (*api_v3.VersionsResponse, error) {
+	protocols := make([]string, 0, len(s.v2.engines))
+	for protocol := range s.v2.engines {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+
+	return &api_v3.VersionsResponse{
+		Versions:  supportedVersions,
+		Group:     s.v2.params.Group().Name(),
+		Protocols: protocols,
+		ServerId:  s.v2.serverID(),
+	}, nil
+}
+
+// GetParameters: returns the active parameter set in its canonical encoding, with its fingerprint
+func (s *authServerV3) GetParameters(ctx context.Context, req *api_v3.ParametersRequest) (*api_v3.ParametersResponse, error) {
+	params, err := s.v2.params.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_v3.ParametersResponse{
+		Sets: []*api_v3.ParameterSet{{
+			Group:       s.v2.params.Group().Name(),
+			Params:      params,
+			Fingerprint: s.v2.params.Fingerprint(),
+		}},
+	}, nil
+}
+
+func (s *authServerV3) Register(ctx context.Context, req *api_v3.RegisterRequest) (*api_v3.RegisterResponse, error) {
+	protocol, err := s.checkHeader(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	reg := registration{
+		user:     req.User,
+		protocol: protocol,
+		key:      cp_zkp.Statement{Y1: d.element(req.Y1, "y1"), Y2: d.element(req.Y2, "y2")},
+		kdf:      kdfToV2(req.Kdf),
+		proof:    d.proof(req.Proof, "proof"),
+	}
+	if req.Device != nil {
+		reg.device = &deviceRegistration{
+			key:   cp_zkp.Statement{Y1: d.element(req.Device.Y1, "device.y1"), Y2: d.element(req.Device.Y2, "device.y2")},
+			proof: d.proof(req.Device.Proof, "device.proof"),
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	// v3 clients derive `x` with the stored KDF params at login, so an account without them
+	// could never log in
+	if req.Kdf == nil {
+		return nil, missing("kdf")
+	}
+
+	if err := s.v2.register(reg); err != nil {
+		return nil, err
+	}
+	return &api_v3.RegisterResponse{}, nil
+}
+
+// GetKDFParams: returns the KDF params a registered user derives `x` with. They are public,
+// as in `GetGroup`, and let the client hedge the nonce of its login commitment with `x`.
+func (s *authServerV3) GetKDFParams(ctx context.Context, req *api_v3.KDFParamsRequest) (*api_v3.KDFParamsResponse, error) {
+	protocol, err := s.checkHeader(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccount(req.User, protocol); err != nil {
+		return nil, err
+	}
+
+	s.v2.mu.Lock()
+	regParams, userExists := s.v2.RegDir[req.User]
+	s.v2.mu.Unlock()
+	if !userExists {
+		return nil, fmt.Errorf("user %s is not registered on the server", req.User)
+	}
+
+	return &api_v3.KDFParamsResponse{Kdf: kdfToV3(regParams.kdf)}, nil
+}
+
+func (s *authServerV3) CreateAuthenticationChallenge(ctx context.Context, req *api_v3.AuthenticationChallengeRequest) (
+	*api_v3.AuthenticationChallengeResponse, error) {
+
+	protocol, err := s.checkHeader(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccount(req.User, protocol); err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	login := loginCommitment{
+		user:       req.User,
+		commitment: cp_zkp.Commitment{R1: d.element(req.R1, "r1"), R2: d.element(req.R2, "r2")},
+	}
+	if req.Device != nil {
+		login.device = &cp_zkp.Commitment{R1: d.element(req.Device.R1, "device.r1"), R2: d.element(req.Device.R2, "device.r2")}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	challenge, err := s.v2.createAuthenticationChallenge(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_v3.AuthenticationChallengeResponse{
+		AuthId: challenge.authID,
+		C:      s.v2.params.EncodeScalar(challenge.c),
+		Kdf:    kdfToV3(challenge.kdf),
+	}, nil
+}
+
+func (s *authServerV3) VerifyAuthentication(ctx context.Context, req *api_v3.AuthenticationAnswerRequest) (
+	*api_v3.AuthenticationAnswerResponse, error) {
+
+	protocol, err := s.checkHeader(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccount(s.authUser(req.AuthId), protocol); err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	answer := loginAnswer{
+		authID:  req.AuthId,
+		s:       d.scalar(req.S, "s"),
+		deviceS: d.scalar(req.DeviceS, "device_s"),
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	sessionID, err := s.v2.verifyAuthentication(answer)
+	if err != nil {
+		return nil, err
+	}
+	return &api_v3.AuthenticationAnswerResponse{SessionId: sessionID}, nil
+}
+
+func (s *authServerV3) GetGroup(ctx context.Context, req *api_v3.GroupRequest) (*api_v3.GroupResponse, error) {
+	if err := s.checkGroupHeader(req.Header); err != nil {
+		return nil, err
+	}
+
+	members, err := s.v2.groupMembers(req.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &api_v3.GroupResponse{}
+	for _, member := range members {
+		res.Members = append(res.Members, &api_v3.GroupMember{
+			User: member.user,
+			Y1:   member.key.Y1.Bytes(),
+			Y2:   member.key.Y2.Bytes(),
+			Kdf:  kdfToV3(member.kdf),
+		})
+	}
+	return res, nil
+}
+
+func (s *authServerV3) CreateGroupAuthenticationChallenge(ctx context.Context, req *api_v3.GroupAuthenticationChallengeRequest) (
+	*api_v3.GroupAuthenticationChallengeResponse, error) {
+
+	if err := s.checkGroupHeader(req.Header); err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	login := groupCommitment{group: req.Group, users: req.Users}
+	for i, commitment := range req.Commitments {
+		login.commitments = append(login.commitments, cp_zkp.Commitment{
+			R1: d.element(commitment.R1, fmt.Sprintf("commitments[%d].r1", i)),
+			R2: d.element(commitment.R2, fmt.Sprintf("commitments[%d].r2", i)),
+		})
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	authID, c, err := s.v2.createGroupAuthenticationChallenge(login)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_v3.GroupAuthenticationChallengeResponse{
+		AuthId: authID,
+		C:      s.v2.params.EncodeScalar(c),
+	}, nil
+}
+
+func (s *authServerV3) VerifyGroupAuthentication(ctx context.Context, req *api_v3.GroupAuthenticationAnswerRequest) (
+	*api_v3.GroupAuthenticationAnswerResponse, error) {
+
+	if err := s.checkGroupHeader(req.Header); err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	answer := groupAnswer{authID: req.AuthId}
+	for i, response := range req.Responses {
+		answer.responses = append(answer.responses, GroupResponse{
+			C: d.scalar(response.C, fmt.Sprintf("responses[%d].c", i)),
+			S: d.scalar(response.S, fmt.Sprintf("responses[%d].s", i)),
+		})
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	sessionID, group, err := s.v2.verifyGroupAuthentication(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api_v3.GroupAuthenticationAnswerResponse{
+		SessionId: sessionID,
+		Group:     group,
+	}, nil
+}
+
+func (s *authServerV3) RotateCredential(ctx context.Context, req *api_v3.RotateCredentialRequest) (
+	*api_v3.RotateCredentialResponse, error) {
+
+	protocol, err := s.checkHeader(req.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccount(s.authUser(req.AuthId), protocol); err != nil {
+		return nil, err
+	}
+
+	d := s.decoder()
+	rotation := credentialRotation{
+		answer: loginAnswer{
+			authID:  req.AuthId,
+			s:       d.scalar(req.S, "s"),
+			deviceS: d.scalar(req.DeviceS, "device_s"),
+		},
+		key:   cp_zkp.Statement{Y1: d.element(req.Y1, "y1"), Y2: d.element(req.Y2, "y2")},
+		kdf:   kdfToV2(req.Kdf),
+		proof: d.proof(req.Proof, "proof"),
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	// The new credential is derived with new KDF params, which the next login needs
+	if req.Kdf == nil {
+		return nil, missing("kdf")
+	}
+
+	if err := s.v2.rotateCredential(rotation); err != nil {
+		return nil, err
+	}
+	return &api_v3.RotateCredentialResponse{}, nil
+}
+
+// checkHeader checks that a request speaks v3 over the server's group with a protocol the
+// server accepts, and returns that protocol. The empty protocol is Chaum-Pedersen.
+func (s *authServerV3) checkHeader(header *api_v3.Header) (string, error) {
+	if header == nil {
+		return "", grpc_err.ErrInvalidArgument{Field: "header", Reason: "missing"}
+	}
+
+	if header.Version != api_v3.Version_VERSION_3 {
+		return "", grpc_err.ErrInvalidArgument{Field: "header.version", Reason: fmt.Sprintf("%s is not served by this service", header.Version)}
+	}
+
+	if group := s.v2.params.Group().Name(); header.Group != group {
+		return "", grpc_err.ErrInvalidArgument{Field: "header.group", Reason: fmt.Sprintf("the server runs over the %s group", group)}
+	}
+
+	protocol := header.Protocol
+	if protocol == "" {
+		protocol = cp_zkp.ProtocolChaumPedersen
+	}
+	if _, ok := s.v2.engines[protocol]; !ok {
+		return "", grpc_err.ErrInvalidArgument{Field: "header.protocol", Reason: "not accepted by this server"}
+	}
+	return protocol, nil
+}
+
+// checkGroupHeader checks the header of an anonymous group login, which uses `groupProtocol`
+func (s *authServerV3) checkGroupHeader(header *api_v3.Header) error {
+	protocol, err := s.checkHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if protocol != groupProtocol {
+		return grpc_err.ErrInvalidArgument{Field: "header.protocol", Reason: fmt.Sprintf("group logins use the %s protocol", groupProtocol)}
+	}
+	return nil
+}
+
+// checkAccount checks that `user` registered with `protocol`. Unknown users are left to
+// the shared handler, which reports them.
+func (s *authServerV3) checkAccount(user, protocol string) error {
+	s.v2.mu.Lock()
+	regParams, userExists := s.v2.RegDir[user]
+	s.v2.mu.Unlock()
+
+	if userExists && regParams.protocol != protocol {
+		return grpc_err.ErrInvalidArgument{Field: "header.protocol", Reason: fmt.Sprintf("the account uses the %s protocol", regParams.protocol)}
+	}
+	return nil
+}
+
+// authUser returns the user of the pending login `authID`, empty if there is none
+func (s *authServerV3) authUser(authID string) string {
+	s.v2.mu.Lock()
+	defer s.v2.mu.Unlock()
+	return s.v2.AuthDir[authID].user
+}
+
+// decoder decodes the binary values of a v3 request
+func (s *authServerV3) decoder() *v3Decoder {
+	return &v3Decoder{params: s.v2.params}
+}
+
+// v3Decoder decodes elements and scalars with `DecodeElement` and `DecodeScalar` and keeps
+// the first failure. Empty values are nil, for the handlers to reject when required.
+type v3Decoder struct {
+	params *cp_zkp.CPZKPParams
+	err    error
+}
+
+func (d *v3Decoder) element(b []byte, field string) cp_zkp.Element {
+	if len(b) == 0 || d.err != nil {
+		return nil
+	}
+
+	e, err := d.params.DecodeElement(b, field)
+	if err != nil {
+		d.err = invalidArgument(err)
+		return nil
+	}
+	return e
+}
+
+func (d *v3Decoder) scalar(b []byte, field string) *big.Int {
+	if len(b) == 0 || d.err != nil {
+		return nil
+	}
+
+	v, err := d.params.DecodeScalar(b, field)
+	if err != nil {
+		d.err = invalidArgument(err)
+		return nil
+	}
+	return v
+}
+
+func (d *v3Decoder) proof(proof *api_v3.Proof, field string) *Proof {
+	if proof == nil {
+		return nil
+	}
+
+	return &Proof{
+		R1: d.element(proof.R1, field+".r1"),
+		R2: d.element(proof.R2, field+".r2"),
+		C:  d.scalar(proof.C, field+".c"),
+		S:  d.scalar(proof.S, field+".s"),
+	}
+}
+
+// kdfToV2 converts the KDF params of a v3 request
+func kdfToV2(kdf *api_v3.KDFParams) *api.KDFParams {
+	if kdf == nil {
+		return nil
+	}
+
+	return &api.KDFParams{
+		Algorithm:   kdf.Algorithm,
+		Salt:        kdf.Salt,
+		Time:        kdf.Time,
+		Memory:      kdf.Memory,
+		Parallelism: kdf.Parallelism,
+	}
+}
+
+// kdfToV3 converts the KDF params stored with an account
+func kdfToV3(kdf *api.KDFParams) *api_v3.KDFParams {
+	if kdf == nil {
+		return nil
+	}
+
+	return &api_v3.KDFParams{
+		Algorithm:   kdf.Algorithm,
+		Salt:        kdf.Salt,
+		Time:        kdf.Time,
+		Memory:      kdf.Memory,
+		Parallelism: kdf.Parallelism,
+	}
+}
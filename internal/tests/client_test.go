@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
 	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	api_v3 "github.com/srinathLN7/zkp_auth/api/v3/proto"
 	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
 	"github.com/srinathLN7/zkp_auth/internal/server"
 	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
@@ -23,6 +24,34 @@ func SetupGRPCClient(t *testing.T, fn func(*server.Config)) (
 	grpcClient api.AuthClient,
 	cfg *server.Config,
 	teardown func(),
+) {
+	t.Helper()
+
+	grpcClient, _, cfg, teardown = SetupGRPCClients(t, fn)
+	return grpcClient, cfg, teardown
+}
+
+// SetupGRPCClients: sets up a v2 and a v3 grpc client of the same server given the server config
+func SetupGRPCClients(t *testing.T, fn func(*server.Config)) (
+	grpcClient api.AuthClient,
+	grpcClientV3 api_v3.AuthClient,
+	cfg *server.Config,
+	teardown func(),
+) {
+	t.Helper()
+
+	grpcClient, grpcClientV3, _, cfg, teardown = SetupGRPCClientsWithAdmin(t, fn)
+	return grpcClient, grpcClientV3, cfg, teardown
+}
+
+// SetupGRPCClientsWithAdmin: sets up a v2 and a v3 grpc client of the same server given the
+// server config, and returns the admin handle of the server
+func SetupGRPCClientsWithAdmin(t *testing.T, fn func(*server.Config)) (
+	grpcClient api.AuthClient,
+	grpcClientV3 api_v3.AuthClient,
+	admin *server.Admin,
+	cfg *server.Config,
+	teardown func(),
 ) {
 	// Helper marks the calling function as a test helper function.
 	// When printing file and line information, that function will be skipped
@@ -48,7 +77,7 @@ func SetupGRPCClient(t *testing.T, fn func(*server.Config)) (
 		fn(cfg)
 	}
 
-	grpcServer, err := server.NewGRPCSever(cfg)
+	grpcServer, admin, err := server.NewGRPCServerWithAdmin(cfg)
 	require.NoError(t, err)
 
 	go func() {
@@ -56,14 +85,30 @@ func SetupGRPCClient(t *testing.T, fn func(*server.Config)) (
 	}()
 
 	grpcClient = api.NewAuthClient(cc)
+	grpcClientV3 = api_v3.NewAuthClient(cc)
 
-	return grpcClient, cfg, func() {
+	return grpcClient, grpcClientV3, admin, cfg, func() {
 		grpcServer.Stop()
 		cc.Close()
 		listener.Close()
 	}
 }
 
+// registrationProof creates the proof that y1 and y2 share the same exponent sent with a registration
+func registrationProof(t *testing.T, prover *cp_zkp.Prover, cpzkpParams *cp_zkp.CPZKPParams, user string) *api.Proof {
+	t.Helper()
+
+	proof, err := prover.CreateNIProof(cpzkpParams, cp_zkp.RegistrationContext(user))
+	require.NoError(t, err)
+
+	return &api.Proof{
+		R1: proof.R1.String(),
+		R2: proof.R2.String(),
+		C:  proof.C.String(),
+		S:  proof.S.String(),
+	}
+}
+
 // ClientRegisterUserSuccess : Tests registering the client on the server successfull sceanario
 func testClientRegisterUserSuccess(t *testing.T, grpcClient api.AuthClient, config *server.Config) {
 	ctx := context.Background()
@@ -87,9 +132,10 @@ func testClientRegisterUserSuccess(t *testing.T, grpcClient api.AuthClient, conf
 	recvResp, err := grpcClient.Register(
 		ctx,
 		&api.RegisterRequest{
-			User: "srinath",
-			Y1:   y1.String(),
-			Y2:   y2.String(),
+			User:  "srinath",
+			Y1:    y1.String(),
+			Y2:    y2.String(),
+			Proof: registrationProof(t, prover, cpzkpParams, "srinath"),
 		},
 	)
 
@@ -122,9 +168,10 @@ func testClientRegisterUserFail(t *testing.T, grpcClient api.AuthClient, config
 	_, err = grpcClient.Register(
 		ctx,
 		&api.RegisterRequest{
-			User: "srinath",
-			Y1:   y1.String(),
-			Y2:   y2.String(),
+			User:  "srinath",
+			Y1:    y1.String(),
+			Y2:    y2.String(),
+			Proof: registrationProof(t, prover, cpzkpParams, "srinath"),
 		},
 	)
 
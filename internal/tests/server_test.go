@@ -1,8 +1,27 @@
 package test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+
+	grpc_err "github.com/srinathLN7/zkp_auth/api/v2/err"
+	api "github.com/srinathLN7/zkp_auth/api/v2/proto"
+	api_v3 "github.com/srinathLN7/zkp_auth/api/v3/proto"
+	"github.com/srinathLN7/zkp_auth/internal/client"
+	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
+	"github.com/srinathLN7/zkp_auth/internal/server"
+	"github.com/srinathLN7/zkp_auth/lib/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Run the tests
@@ -40,3 +59,1447 @@ func TestGRPCServer(t *testing.T) {
 	})
 
 }
+
+func TestGRPCServerEC(t *testing.T) {
+
+	// Run the same scenarios against a server running over the P-256 curve
+	grpcClient, config, teardown := SetupGRPCClient(t, func(cfg *server.Config) {
+		cpzkp, err := cp_zkp.NewCPZKPWithGroup(cp_zkp.GroupP256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.CPZKP = cpzkp
+	})
+
+	defer teardown()
+
+	t.Run("register user succesfully", func(t *testing.T) {
+		testClientRegisterUserSuccess(t, grpcClient, config)
+	})
+
+	t.Run("verification proof successful", func(t *testing.T) {
+		testClientVerifyProofSuccess(t, grpcClient, config)
+	})
+
+	t.Run("verification proof failure", func(t *testing.T) {
+		testClientVerifyProofFail(t, grpcClient, config)
+	})
+}
+
+func TestGRPCServerPreset(t *testing.T) {
+
+	// Run the same scenarios against a server using the RFC 7919 ffdhe2048 group
+	grpcClient, config, teardown := SetupGRPCClient(t, func(cfg *server.Config) {
+		cfg.CPZKP = &cp_zkp.CPZKP{Group: cp_zkp.GroupModP, Preset: cp_zkp.PresetFFDHE2048}
+	})
+
+	defer teardown()
+
+	t.Run("register user succesfully", func(t *testing.T) {
+		testClientRegisterUserSuccess(t, grpcClient, config)
+	})
+
+	t.Run("verification proof successful", func(t *testing.T) {
+		testClientVerifyProofSuccess(t, grpcClient, config)
+	})
+
+	t.Run("verification proof failure", func(t *testing.T) {
+		testClientVerifyProofFail(t, grpcClient, config)
+	})
+}
+
+func TestGRPCServerRejectsWeakParams(t *testing.T) {
+
+	// A toy parameter set with a 5-bit prime must never be served
+	paramFile := filepath.Join(t.TempDir(), "params.json")
+	err := cp_zkp.WriteParamFile(paramFile, &cp_zkp.ParamFile{
+		Group: cp_zkp.GroupModP,
+		Bits:  5,
+		P:     "23",
+		Q:     "11",
+		G:     "4",
+		H:     "9",
+		Seed:  "00",
+	})
+	require.NoError(t, err)
+
+	cfg := &server.Config{
+		CPZKP: &cp_zkp.CPZKP{Group: cp_zkp.GroupModP, ParamFile: paramFile},
+	}
+
+	_, err = server.NewGRPCSever(cfg)
+	require.ErrorIs(t, err, cp_zkp.ErrParamTooSmall)
+}
+
+func TestGRPCServerBatch(t *testing.T) {
+
+	// Concurrent logins are verified together by the batch queue
+	grpcClient, config, teardown := SetupGRPCClient(t, func(cfg *server.Config) {
+		cfg.BatchWindow = 20 * time.Millisecond
+		cfg.BatchSize = 8
+	})
+
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	const users = 12
+	provers := make([]*cp_zkp.Prover, users)
+	for i := range provers {
+		provers[i] = cp_zkp.NewProver(big.NewInt(int64(1000 + i)))
+		y1, y2 := provers[i].GenerateYValues(cpzkpParams)
+		user := fmt.Sprintf("user-%d", i)
+		_, err := grpcClient.Register(ctx, &api.RegisterRequest{
+			User:  user,
+			Y1:    y1.String(),
+			Y2:    y2.String(),
+			Proof: registrationProof(t, provers[i], cpzkpParams, user),
+		})
+		require.NoError(t, err)
+	}
+
+	// Every third user answers with a wrong secret value
+	var wg sync.WaitGroup
+	errs := make([]error, users)
+	for i := range provers {
+		prover := provers[i]
+		if i%3 == 0 {
+			prover = cp_zkp.NewProver(big.NewInt(7))
+		}
+
+		wg.Add(1)
+		go func(i int, prover *cp_zkp.Prover) {
+			defer wg.Done()
+			errs[i] = login(ctx, grpcClient, cpzkpParams, prover, fmt.Sprintf("user-%d", i))
+		}(i, prover)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if i%3 == 0 {
+			require.Error(t, err, "user-%d logged in with a wrong secret", i)
+		} else {
+			require.NoError(t, err, "user-%d failed to log in", i)
+		}
+	}
+}
+
+// login runs the interactive login of `user` with the given prover
+func login(ctx context.Context, grpcClient api.AuthClient, cpzkpParams *cp_zkp.CPZKPParams, prover *cp_zkp.Prover, user string) error {
+	k, r1, r2, err := prover.CreateProofCommitment(cpzkpParams)
+	if err != nil {
+		return err
+	}
+
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+		User: user,
+		R1:   r1.String(),
+		R2:   r2.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	c, err := util.ParseBigInt(challengeRes.C, "c")
+	if err != nil {
+		return err
+	}
+
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+		AuthId: challengeRes.AuthId,
+		S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+	})
+	return err
+}
+
+func TestGRPCServerKDF(t *testing.T) {
+
+	// The KDF salt and cost chosen at registration are returned at login
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	_, err = client.Register(grpcClientV3, cpzkp, "alice", "correct horse battery staple", kdf)
+	require.NoError(t, err)
+
+	logInRes, err := client.LogIn(grpcClientV3, cpzkp, "alice", "correct horse battery staple")
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(context.Background(), &api.AuthenticationChallengeRequest{
+		User: "alice",
+		R1:   "4",
+		R2:   "9",
+	})
+	require.NoError(t, err)
+	require.Equal(t, kdf.Salt, challengeRes.Kdf.Salt)
+	require.Equal(t, kdf.Memory, challengeRes.Kdf.Memory)
+
+	// A registration with a salt that is too short is refused
+	_, err = grpcClient.Register(context.Background(), &api.RegisterRequest{
+		User: "bob",
+		Y1:   "4",
+		Y2:   "9",
+		Kdf: &api.KDFParams{
+			Algorithm:   cp_zkp.KDFArgon2id,
+			Salt:        []byte("salt"),
+			Time:        1,
+			Memory:      cp_zkp.MinKDFMemory,
+			Parallelism: 1,
+		},
+	})
+	require.Error(t, err)
+}
+
+// repeatReader is a broken RNG that returns the same byte forever
+type repeatReader byte
+
+func (r repeatReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = byte(r)
+	}
+	return len(b), nil
+}
+
+func TestGRPCServerHedgedLogin(t *testing.T) {
+
+	// The client derives `x` before it commits, so the login nonces are hedged with `x` and
+	// the login context: two logins with a repeating RNG still commit with distinct nonces.
+	// The server refuses a repeated commitment, so the second login would fail otherwise.
+	_, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	_, err = client.Register(grpcClientV3, cpzkp, "alice", "alice-password", kdf)
+	require.NoError(t, err)
+
+	cpzkp.Rand = repeatReader(0x42)
+	defer func() { cpzkp.Rand = nil }()
+
+	for i := 0; i < 2; i++ {
+		logInRes, err := client.LogIn(grpcClientV3, cpzkp, "alice", "alice-password")
+		require.NoError(t, err)
+		require.NotEmpty(t, logInRes.SessionId)
+	}
+
+	// The KDF params are public, and only exist for registered users
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+	header := &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cpzkpParams.Group().Name()}
+
+	kdfRes, err := grpcClientV3.GetKDFParams(context.Background(), &api_v3.KDFParamsRequest{Header: header, User: "alice"})
+	require.NoError(t, err)
+	require.Equal(t, kdf.Salt, kdfRes.Kdf.Salt)
+
+	_, err = grpcClientV3.GetKDFParams(context.Background(), &api_v3.KDFParamsRequest{Header: header, User: "bob"})
+	require.Error(t, err)
+}
+
+func TestGRPCServerRegistrationProof(t *testing.T) {
+
+	// Registrations must prove that y1 and y2 share the same exponent
+	grpcClient, config, teardown := SetupGRPCClient(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	_, otherY2 := cp_zkp.NewProver(big.NewInt(4321)).GenerateYValues(cpzkpParams)
+
+	tests := []struct {
+		name string
+		req  *api.RegisterRequest
+		err  error
+	}{
+		{
+			name: "missing proof",
+			req:  &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String()},
+			err:  grpc_err.ErrInvalidRegistrationProof{User: "alice", Reason: "missing proof"},
+		},
+		{
+			name: "different exponents",
+			req: &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: otherY2.String(),
+				Proof: registrationProof(t, prover, cpzkpParams, "alice")},
+			err: grpc_err.ErrInvalidRegistrationProof{User: "alice", Reason: "y1 and y2 do not share the same exponent"},
+		},
+		{
+			name: "proof for another user",
+			req: &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+				Proof: registrationProof(t, prover, cpzkpParams, "bob")},
+			err: grpc_err.ErrInvalidRegistrationProof{User: "alice", Reason: "y1 and y2 do not share the same exponent"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := grpcClient.Register(ctx, tc.req)
+			require.Error(t, err)
+			require.Equal(t, tc.err.Error(), err.Error())
+		})
+	}
+
+	// Nothing was written for the rejected registrations
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+		Proof: registrationProof(t, prover, cpzkpParams, "alice")})
+	require.NoError(t, err)
+}
+
+// requireInvalidArgument checks that `err` is an `InvalidArgument` status naming `field` only
+func requireInvalidArgument(t *testing.T, err error, field string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var violations []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				violations = append(violations, v.Field)
+			}
+		}
+	}
+	require.Equal(t, []string{field}, violations)
+}
+
+func TestGRPCServerInvalidArguments(t *testing.T) {
+
+	// Malformed client-supplied values are rejected with `InvalidArgument` naming the field
+	grpcClient, config, teardown := SetupGRPCClient(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	proof := registrationProof(t, prover, cpzkpParams, "alice")
+
+	p := cpzkpParams.Group().(*cp_zkp.ModPGroup).P()
+	pMinusOne := new(big.Int).Sub(p, big.NewInt(1)).String()
+
+	registrations := []struct {
+		field string
+		req   *api.RegisterRequest
+	}{
+		{"y1", &api.RegisterRequest{User: "alice", Y1: "0", Y2: y2.String(), Proof: proof}},
+		{"y1", &api.RegisterRequest{User: "alice", Y1: p.String(), Y2: y2.String(), Proof: proof}},
+		{"y2", &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: pMinusOne, Proof: proof}},
+		{"y2", &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: "1", Proof: proof}},
+		{"y2", &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: "0" + y2.String(), Proof: proof}},
+		{"proof.s", &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+			Proof: &api.Proof{R1: proof.R1, R2: proof.R2, C: proof.C, S: "-1"}}},
+	}
+
+	for _, tc := range registrations {
+		_, err := grpcClient.Register(ctx, tc.req)
+		requireInvalidArgument(t, err, tc.field)
+	}
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(), Proof: proof})
+	require.NoError(t, err)
+
+	_, err = grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{User: "alice", R1: pMinusOne, R2: "9"})
+	requireInvalidArgument(t, err, "r1")
+
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{User: "alice", R1: "4", R2: "9"})
+	require.NoError(t, err)
+
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+		AuthId: challengeRes.AuthId,
+		S:      cpzkpParams.Group().Order().String(),
+	})
+	requireInvalidArgument(t, err, "s")
+}
+
+func TestGRPCServerMissingValues(t *testing.T) {
+
+	// Both services decode their own wire format and hand the same values to the handlers,
+	// so a value that was not sent is reported on the same field by both
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	proof := registrationProof(t, prover, cpzkpParams, "alice")
+
+	r1, err := cpzkpParams.ParseElement(proof.R1, "r1")
+	require.NoError(t, err)
+	r2, err := cpzkpParams.ParseElement(proof.R2, "r2")
+	require.NoError(t, err)
+	c, err := cpzkpParams.ParseScalar(proof.C, "c")
+	require.NoError(t, err)
+
+	s, err := cpzkpParams.ParseScalar(proof.S, "s")
+	require.NoError(t, err)
+
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdfV3 := &api_v3.KDFParams{Algorithm: kdf.Algorithm, Salt: kdf.Salt, Time: 1, Memory: cp_zkp.MinKDFMemory, Parallelism: 1}
+
+	header := &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cp_zkp.GroupModP}
+	proofV3 := &api_v3.Proof{R1: r1.Bytes(), R2: r2.Bytes(), C: cpzkpParams.EncodeScalar(c)}
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Proof: proof})
+	requireInvalidArgument(t, err, "y2")
+
+	_, err = grpcClientV3.Register(ctx, &api_v3.RegisterRequest{Header: header, User: "alice", Y1: y1.Bytes(), Kdf: kdfV3, Proof: proofV3})
+	requireInvalidArgument(t, err, "y2")
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+		Proof: &api.Proof{R1: proof.R1, R2: proof.R2, C: proof.C}})
+	requireInvalidArgument(t, err, "proof.s")
+
+	_, err = grpcClientV3.Register(ctx, &api_v3.RegisterRequest{Header: header, User: "alice", Y1: y1.Bytes(), Y2: y2.Bytes(), Kdf: kdfV3, Proof: proofV3})
+	requireInvalidArgument(t, err, "proof.s")
+
+	// v3 clients derive `x` with the stored KDF params at login, so v3 requires them
+	proofV3.S = cpzkpParams.EncodeScalar(s)
+	_, err = grpcClientV3.Register(ctx, &api_v3.RegisterRequest{Header: header, User: "alice", Y1: y1.Bytes(), Y2: y2.Bytes(), Proof: proofV3})
+	requireInvalidArgument(t, err, "kdf")
+
+	_, err = grpcClientV3.RotateCredential(ctx, &api_v3.RotateCredentialRequest{Header: header, AuthId: "auth", S: cpzkpParams.EncodeScalar(s),
+		Y1: y1.Bytes(), Y2: y2.Bytes(), Proof: proofV3})
+	requireInvalidArgument(t, err, "kdf")
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(), Proof: proof})
+	require.NoError(t, err)
+
+	_, err = grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{User: "alice", R2: "9"})
+	requireInvalidArgument(t, err, "r1")
+
+	_, err = grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{Header: header, User: "alice", R2: r2.Bytes()})
+	requireInvalidArgument(t, err, "r1")
+
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{User: "alice", R1: "4", R2: "9"})
+	require.NoError(t, err)
+
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{AuthId: challengeRes.AuthId})
+	requireInvalidArgument(t, err, "s")
+
+	challengeResV3, err := grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{
+		Header: header,
+		User:   "alice",
+		R1:     r1.Bytes(),
+		R2:     r2.Bytes(),
+	})
+	require.NoError(t, err)
+
+	_, err = grpcClientV3.VerifyAuthentication(ctx, &api_v3.AuthenticationAnswerRequest{Header: header, AuthId: challengeResV3.AuthId})
+	requireInvalidArgument(t, err, "s")
+}
+
+func TestGRPCServerCommitmentReuse(t *testing.T) {
+
+	// A commitment answered in two verified sessions flags the account. With a history of
+	// one commitment, a fresh challenge evicts the first one, so the reuse gets challenged.
+	var mu sync.Mutex
+	var events []server.SecurityEvent
+	grpcClient, _, admin, config, teardown := SetupGRPCClientsWithAdmin(t, func(cfg *server.Config) {
+		cfg.CommitmentHistory = 1
+		cfg.OnSecurityEvent = func(event server.SecurityEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+		Proof: registrationProof(t, prover, cpzkpParams, "alice")})
+	require.NoError(t, err)
+
+	k, r1, r2, err := prover.CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+	challengeReq := &api.AuthenticationChallengeRequest{User: "alice", R1: r1.String(), R2: r2.String()}
+
+	// answer logs in with the commitment (r1, r2)
+	answer := func() (string, error) {
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
+		require.NoError(t, err)
+
+		c, err := util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+
+		_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+			AuthId: challengeRes.AuthId,
+			S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+		})
+		return challengeRes.AuthId, err
+	}
+
+	first, err := answer()
+	require.NoError(t, err)
+
+	// A fresh commitment that is never answered evicts (r1, r2) from the challenged ones
+	_, fresh1, fresh2, err := prover.CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+	_, err = grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+		User: "alice", R1: fresh1.String(), R2: fresh2.String()})
+	require.NoError(t, err)
+
+	// The second verified answer for (r1, r2) is refused and flags the account
+	second, err := answer()
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrAccountCompromised{User: "alice", Reason: "proof commitment (r1, r2) reused"}.Error(), err.Error())
+
+	mu.Lock()
+	require.Len(t, events, 1)
+	require.Equal(t, server.EventCommitmentReuse, events[0].Kind)
+	require.Equal(t, "alice", events[0].User)
+	require.Equal(t, []string{first, second}, events[0].AuthIDs)
+	mu.Unlock()
+
+	// Even a fresh commitment is refused once the account is flagged
+	err = login(ctx, grpcClient, cpzkpParams, prover, "alice")
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrAccountCompromised{User: "alice", Reason: "login disabled"}.Error(), err.Error())
+
+	// ... until the operator clears the flag
+	require.Error(t, admin.ClearCompromised("bob"))
+	require.NoError(t, admin.ClearCompromised("alice"))
+	require.NoError(t, login(ctx, grpcClient, cpzkpParams, prover, "alice"))
+}
+
+func TestGRPCServerCommitmentReplay(t *testing.T) {
+
+	// Resending a commitment that was already challenged is refused and reported without
+	// flagging the account: anyone who saw the commitment can resend it, but only the holder
+	// of `x` can answer, so an unauthenticated replay must not lock the account
+	var mu sync.Mutex
+	var events []server.SecurityEvent
+	grpcClient, config, teardown := SetupGRPCClient(t, func(cfg *server.Config) {
+		cfg.OnSecurityEvent = func(event server.SecurityEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+		Proof: registrationProof(t, prover, cpzkpParams, "alice")})
+	require.NoError(t, err)
+
+	k, r1, r2, err := prover.CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+
+	challengeReq := &api.AuthenticationChallengeRequest{User: "alice", R1: r1.String(), R2: r2.String()}
+	challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
+	require.NoError(t, err)
+
+	// An eavesdropper replays the commitment, before and after the login completes
+	_, err = grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
+	requireInvalidArgument(t, err, "r1")
+
+	c, err := util.ParseBigInt(challengeRes.C, "c")
+	require.NoError(t, err)
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+		AuthId: challengeRes.AuthId,
+		S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = grpcClient.CreateAuthenticationChallenge(ctx, challengeReq)
+		requireInvalidArgument(t, err, "r1")
+	}
+
+	// ... and answers a challenge of its own with a wrong response
+	_, er1, er2, err := cp_zkp.NewProver(big.NewInt(4321)).CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+	evilRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+		User: "alice", R1: er1.String(), R2: er2.String()})
+	require.NoError(t, err)
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{AuthId: evilRes.AuthId, S: "1"})
+	require.Error(t, err)
+
+	// Every replay raised an event naming the session it replayed, but the account is not
+	// flagged, and its owner still logs in
+	require.NoError(t, login(ctx, grpcClient, cpzkpParams, prover, "alice"))
+	mu.Lock()
+	require.Len(t, events, 4)
+	for _, event := range events {
+		require.Equal(t, server.EventCommitmentReplay, event.Kind)
+		require.Equal(t, "alice", event.User)
+		require.Len(t, event.AuthIDs, 2)
+		require.Equal(t, challengeRes.AuthId, event.AuthIDs[0])
+		require.NotEqual(t, challengeRes.AuthId, event.AuthIDs[1])
+	}
+	mu.Unlock()
+}
+
+func TestGRPCServerReplay(t *testing.T) {
+
+	// Each challenge can be answered only once, whether the answer was valid or not
+	grpcClient, config, teardown := SetupGRPCClient(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	prover := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := prover.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Y2: y2.String(),
+		Proof: registrationProof(t, prover, cpzkpParams, "alice")})
+	require.NoError(t, err)
+
+	challenge := func() (*big.Int, *api.AuthenticationChallengeResponse, *big.Int) {
+		k, r1, r2, err := prover.CreateProofCommitment(cpzkpParams)
+		require.NoError(t, err)
+
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+			User: "alice",
+			R1:   r1.String(),
+			R2:   r2.String(),
+		})
+		require.NoError(t, err)
+
+		c, err := util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+		return k, challengeRes, c
+	}
+
+	// A captured valid transcript cannot be resubmitted for another session
+	k, challengeRes, c := challenge()
+	answer := &api.AuthenticationAnswerRequest{
+		AuthId: challengeRes.AuthId,
+		S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+	}
+
+	_, err = grpcClient.VerifyAuthentication(ctx, answer)
+	require.NoError(t, err)
+
+	_, err = grpcClient.VerifyAuthentication(ctx, answer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid authentication id")
+
+	// A wrong answer consumes the challenge too
+	k, challengeRes, c = challenge()
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{AuthId: challengeRes.AuthId, S: "1"})
+	require.Error(t, err)
+
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+		AuthId: challengeRes.AuthId,
+		S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid authentication id")
+}
+
+func TestGRPCServerGroupLogin(t *testing.T) {
+
+	// Members of a group log in anonymously with a disjunctive proof
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, func(cfg *server.Config) {
+		cfg.Groups = map[string][]string{"voters": {"alice", "bob", "carol", "dave"}, "solo": {"alice", "dave"}}
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+
+	for _, user := range []string{"alice", "bob", "eve"} {
+		kdf, err := cp_zkp.NewKDFParams()
+		require.NoError(t, err)
+		kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+		_, err = client.Register(grpcClientV3, cpzkp, user, user+"-password", kdf)
+		require.NoError(t, err)
+	}
+
+	// carol registers with a known secret to drive the RPCs by hand; dave never registers
+	carol := cp_zkp.NewProver(big.NewInt(4242))
+	y1, y2 := carol.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "carol", Y1: y1.String(), Y2: y2.String(),
+		Proof: registrationProof(t, carol, cpzkpParams, "carol")})
+	require.NoError(t, err)
+
+	groupRes, err := grpcClient.GetGroup(ctx, &api.GroupRequest{Group: "voters"})
+	require.NoError(t, err)
+	require.Len(t, groupRes.Members, 3)
+
+	loginRes, err := client.GroupLogIn(grpcClientV3, cpzkp, "voters", "bob", "bob-password")
+	require.NoError(t, err)
+	require.NotEmpty(t, loginRes.SessionId)
+	require.Equal(t, "voters", loginRes.Group)
+
+	_, err = client.GroupLogIn(grpcClientV3, cpzkp, "voters", "bob", "wrong-password")
+	require.ErrorIs(t, err, cp_zkp.ErrNotAWitness)
+
+	_, err = client.GroupLogIn(grpcClientV3, cpzkp, "voters", "eve", "eve-password")
+	require.Error(t, err)
+
+	_, err = client.GroupLogIn(grpcClientV3, cpzkp, "admins", "alice", "alice-password")
+	require.Error(t, err)
+
+	// eve is registered but not a member: she cannot be listed in the proof
+	_, err = grpcClient.CreateGroupAuthenticationChallenge(ctx, &api.GroupAuthenticationChallengeRequest{
+		Group:       "voters",
+		Users:       []string{"eve"},
+		Commitments: []*api.Commitment{{R1: y1.String(), R2: y2.String()}},
+	})
+	require.Error(t, err)
+
+	// carol runs the proof over every member by hand
+	statements := make([]cp_zkp.Statement, len(groupRes.Members))
+	users := make([]string, len(groupRes.Members))
+	for i, member := range groupRes.Members {
+		users[i] = member.User
+		statements[i].Y1, err = cpzkpParams.ParseElement(member.Y1, "y1")
+		require.NoError(t, err)
+		statements[i].Y2, err = cpzkpParams.ParseElement(member.Y2, "y2")
+		require.NoError(t, err)
+	}
+
+	// A list that leaves out eligible members would narrow down who logs in: carol alone,
+	// or carol and bob without alice, is refused
+	for _, subset := range [][]int{{2}, {1, 2}} {
+		subStatements := make([]cp_zkp.Statement, len(subset))
+		challengeReq := &api.GroupAuthenticationChallengeRequest{Group: "voters"}
+		for i, j := range subset {
+			subStatements[i] = statements[j]
+			challengeReq.Users = append(challengeReq.Users, users[j])
+		}
+
+		_, commitments, err := carol.CreateORCommitment(cpzkpParams, subStatements, len(subset)-1)
+		require.NoError(t, err)
+		for _, commitment := range commitments {
+			challengeReq.Commitments = append(challengeReq.Commitments, &api.Commitment{R1: commitment.R1.String(), R2: commitment.R2.String()})
+		}
+
+		_, err = grpcClient.CreateGroupAuthenticationChallenge(ctx, challengeReq)
+		requireInvalidArgument(t, err, "users")
+	}
+
+	// A group with a single eligible member has no anonymity set at all, even listed in full
+	_, err = client.GroupLogIn(grpcClientV3, cpzkp, "solo", "alice", "alice-password")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "anonymous logins need at least 2")
+
+	groupLogin := func(tamper bool) (*api.GroupAuthenticationAnswerRequest, error) {
+		state, commitments, err := carol.CreateORCommitment(cpzkpParams, statements, 2)
+		require.NoError(t, err)
+
+		challengeReq := &api.GroupAuthenticationChallengeRequest{Group: "voters", Users: users}
+		for _, commitment := range commitments {
+			challengeReq.Commitments = append(challengeReq.Commitments, &api.Commitment{R1: commitment.R1.String(), R2: commitment.R2.String()})
+		}
+		challengeRes, err := grpcClient.CreateGroupAuthenticationChallenge(ctx, challengeReq)
+		require.NoError(t, err)
+
+		c, err := util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+
+		answerReq := &api.GroupAuthenticationAnswerRequest{AuthId: challengeRes.AuthId}
+		for _, tr := range carol.CreateORChallengeResponse(state, c, cpzkpParams) {
+			answerReq.Responses = append(answerReq.Responses, &api.Response{C: tr.C.String(), S: tr.S.String()})
+		}
+		if tamper {
+			answerReq.Responses[0].S = "1"
+		}
+
+		_, err = grpcClient.VerifyGroupAuthentication(ctx, answerReq)
+		return answerReq, err
+	}
+
+	answerReq, err := groupLogin(false)
+	require.NoError(t, err)
+
+	// A challenge can only be answered once
+	_, err = grpcClient.VerifyGroupAuthentication(ctx, answerReq)
+	require.Error(t, err)
+
+	_, err = groupLogin(true)
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrInvalidGroupProof{Group: "voters"}.Error(), err.Error())
+}
+
+func TestGRPCServerDeviceKey(t *testing.T) {
+
+	// Two-factor accounts prove the password-derived and the device-held secret together
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	device, err := client.NewDeviceKey(cpzkp)
+	require.NoError(t, err)
+
+	// The device key survives a round trip through its file, which is never overwritten
+	keyFile := filepath.Join(t.TempDir(), "device.key")
+	require.NoError(t, client.WriteDeviceKey(keyFile, device))
+	require.Error(t, client.WriteDeviceKey(keyFile, device))
+	readDevice, err := client.ReadDeviceKey(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, device, readDevice)
+
+	_, err = client.RegisterWithDevice(grpcClientV3, cpzkp, "alice", "alice-password", kdf, device)
+	require.NoError(t, err)
+
+	logInRes, err := client.LogInWithDevice(grpcClientV3, cpzkp, "alice", "alice-password", device)
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+
+	x, err := cp_zkp.DeriveSecret("alice-password", kdf, cpzkpParams)
+	require.NoError(t, err)
+
+	// The password alone is not enough
+	err = login(ctx, grpcClient, cpzkpParams, cp_zkp.NewProver(x), "alice")
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	loginWithDevice := func(device *big.Int, withDeviceS bool) error {
+		provers := []*cp_zkp.Prover{cp_zkp.NewProver(x), cp_zkp.NewProver(device)}
+		k, commitments, err := cp_zkp.CreateANDCommitment(cpzkpParams, provers...)
+		require.NoError(t, err)
+
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+			User:   "alice",
+			R1:     commitments[0].R1.String(),
+			R2:     commitments[0].R2.String(),
+			Device: &api.Commitment{R1: commitments[1].R1.String(), R2: commitments[1].R2.String()},
+		})
+		require.NoError(t, err)
+
+		c, err := util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+
+		s := cp_zkp.CreateANDChallengeResponse(cpzkpParams, provers, k, c)
+		answerReq := &api.AuthenticationAnswerRequest{AuthId: challengeRes.AuthId, S: s[0].String()}
+		if withDeviceS {
+			answerReq.DeviceS = s[1].String()
+		}
+		_, err = grpcClient.VerifyAuthentication(ctx, answerReq)
+		return err
+	}
+
+	require.NoError(t, loginWithDevice(device, true))
+
+	err = loginWithDevice(big.NewInt(7), true)
+	require.Error(t, err)
+	require.Equal(t, codes.Code(401), status.Code(err))
+
+	err = loginWithDevice(device, false)
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The device key must come with its own proof
+	bob := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := bob.GenerateYValues(cpzkpParams)
+	dy1, dy2 := cp_zkp.NewProver(big.NewInt(5678)).GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{
+		User:   "bob",
+		Y1:     y1.String(),
+		Y2:     y2.String(),
+		Proof:  registrationProof(t, bob, cpzkpParams, "bob"),
+		Device: &api.DeviceKey{Y1: dy1.String(), Y2: dy2.String(), Proof: registrationProof(t, bob, cpzkpParams, "bob")},
+	})
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrInvalidRegistrationProof{User: "bob", Reason: "device.proof: y1 and y2 do not share the same exponent"}.Error(), err.Error())
+}
+
+func TestGRPCServerRotateCredential(t *testing.T) {
+
+	// A user replaces its secret by proving the old and the new one under one challenge
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+
+	newKDF := func() *cp_zkp.KDFParams {
+		kdf, err := cp_zkp.NewKDFParams()
+		require.NoError(t, err)
+		kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+		return kdf
+	}
+
+	kdf := newKDF()
+	_, err = client.Register(grpcClientV3, cpzkp, "alice", "old-password", kdf)
+	require.NoError(t, err)
+
+	x, err := cp_zkp.DeriveSecret("old-password", kdf, cpzkpParams)
+	require.NoError(t, err)
+	alice := cp_zkp.NewProver(x)
+
+	challenge := func() (k, c *big.Int, authID string) {
+		k, r1, r2, err := alice.CreateProofCommitment(cpzkpParams)
+		require.NoError(t, err)
+
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+			User: "alice",
+			R1:   r1.String(),
+			R2:   r2.String(),
+		})
+		require.NoError(t, err)
+
+		c, err = util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+		return k, c, challengeRes.AuthId
+	}
+
+	// A login left pending before the rotation
+	pendingK, pendingC, pendingID := challenge()
+
+	// The proof for the new secret must be bound to the answered challenge
+	k, c, authID := challenge()
+	bob := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := bob.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.RotateCredential(ctx, &api.RotateCredentialRequest{
+		AuthId: authID,
+		S:      alice.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+		Y1:     y1.String(),
+		Y2:     y2.String(),
+		Proof:  registrationProof(t, bob, cpzkpParams, "alice"),
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.Code(400), status.Code(err))
+
+	// The old secret must be known
+	_, err = client.RotateCredential(grpcClientV3, cpzkp, "alice", "wrong-password", "new-password", newKDF(), nil)
+	require.Error(t, err)
+	require.Equal(t, codes.Code(401), status.Code(err))
+
+	_, err = client.RotateCredential(grpcClientV3, cpzkp, "alice", "old-password", "new-password", newKDF(), nil)
+	require.NoError(t, err)
+
+	// Pending logins are invalidated, and only the new password logs in
+	_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+		AuthId: pendingID,
+		S:      alice.CreateProofChallengeResponse(pendingK, pendingC, cpzkpParams).String(),
+	})
+	require.Error(t, err)
+
+	err = login(ctx, grpcClient, cpzkpParams, alice, "alice")
+	require.Error(t, err)
+	require.Equal(t, codes.Code(401), status.Code(err))
+
+	logInRes, err := client.LogIn(grpcClientV3, cpzkp, "alice", "new-password")
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+}
+
+func TestGRPCServerSchnorr(t *testing.T) {
+
+	// Accounts registered with the Schnorr protocol prove knowledge of log_g(y1) only
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	schnorr := &cp_zkp.CPZKP{Group: cp_zkp.GroupModP, Protocol: cp_zkp.ProtocolSchnorr}
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	_, err = client.Register(grpcClientV3, schnorr, "alice", "alice-password", kdf)
+	require.NoError(t, err)
+
+	logInRes, err := client.LogIn(grpcClientV3, schnorr, "alice", "alice-password")
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+
+	x, err := cp_zkp.DeriveSecret("alice-password", kdf, cpzkpParams)
+	require.NoError(t, err)
+
+	schnorrLogin := func(prover *cp_zkp.Prover) error {
+		k, r, err := prover.CreateSchnorrCommitment(cpzkpParams)
+		require.NoError(t, err)
+
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+			User: "alice",
+			R1:   r.String(),
+		})
+		require.NoError(t, err)
+
+		c, err := util.ParseBigInt(challengeRes.C, "c")
+		require.NoError(t, err)
+
+		_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{
+			AuthId: challengeRes.AuthId,
+			S:      prover.CreateProofChallengeResponse(k, c, cpzkpParams).String(),
+		})
+		return err
+	}
+
+	require.NoError(t, schnorrLogin(cp_zkp.NewProver(x)))
+
+	err = schnorrLogin(cp_zkp.NewProver(new(big.Int).Add(x, big.NewInt(1))))
+	require.Error(t, err)
+	require.Equal(t, codes.Code(401), status.Code(err))
+
+	// A Chaum-Pedersen commitment is refused for a Schnorr account
+	err = login(ctx, grpcClient, cpzkpParams, cp_zkp.NewProver(x), "alice")
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrInvalidArgument{Field: "r2", Reason: "not used by the schnorr protocol"}.Error(), err.Error())
+
+	// Schnorr registrations carry no `y2`, and a Chaum-Pedersen proof does not pass
+	bob := cp_zkp.NewProver(big.NewInt(1234))
+	y1, y2 := bob.GenerateYValues(cpzkpParams)
+	schnorrProof, err := bob.CreateSchnorrNIProof(cpzkpParams, cp_zkp.RegistrationContext("bob"))
+	require.NoError(t, err)
+	proof := &api.Proof{R1: schnorrProof.R.String(), C: schnorrProof.C.String(), S: schnorrProof.S.String()}
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Y2: y2.String(), Proof: proof, Protocol: cp_zkp.ProtocolSchnorr})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: registrationProof(t, bob, cpzkpParams, "bob"), Protocol: cp_zkp.ProtocolSchnorr})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: proof, Protocol: "okamoto"})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: proof, Protocol: cp_zkp.ProtocolSchnorr})
+	require.NoError(t, err)
+
+	// The account keeps its protocol across a credential rotation
+	_, err = client.RotateCredential(grpcClientV3, schnorr, "alice", "alice-password", "new-password", kdf, nil)
+	require.NoError(t, err)
+
+	logInRes, err = client.LogIn(grpcClientV3, schnorr, "alice", "new-password")
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+
+	// A server can restrict registrations to one protocol
+	grpcClient, _, teardown = SetupGRPCClient(t, func(cfg *server.Config) {
+		cfg.CPZKP.(*cp_zkp.CPZKP).Protocol = cp_zkp.ProtocolChaumPedersen
+	})
+	defer teardown()
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: proof, Protocol: cp_zkp.ProtocolSchnorr})
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrInvalidArgument{Field: "protocol", Reason: "not accepted by this server"}.Error(), err.Error())
+}
+
+// fakeEngine is a proof system for testing the RPC handlers: it accepts a proof of
+// possession with `c` = 1, always issues the challenge 42 and accepts the answer 42
+type fakeEngine struct {
+	mu     sync.Mutex
+	issued []server.AuthContext
+}
+
+func (e *fakeEngine) Protocol() string {
+	return "fake"
+}
+
+func (e *fakeEngine) CheckPublicKey(key cp_zkp.Statement, field string) error {
+	return nil
+}
+
+func (e *fakeEngine) VerifyPossession(key cp_zkp.Statement, proof server.Proof, context []byte, field string) (bool, error) {
+	return proof.C != nil && proof.C.Cmp(big.NewInt(1)) == 0, nil
+}
+
+func (e *fakeEngine) CheckCommitment(commitment cp_zkp.Commitment, field string) error {
+	return nil
+}
+
+func (e *fakeEngine) IssueChallenge(auth server.AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (*big.Int, []byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.issued = append(e.issued, auth)
+	return big.NewInt(42), []byte(auth.AuthID), nil
+}
+
+func (e *fakeEngine) Verify(auth server.AuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, s []*big.Int) bool {
+	return string(nonce) == auth.AuthID && len(s) == 1 && s[0].Cmp(c) == 0
+}
+
+func TestGRPCServerEngine(t *testing.T) {
+
+	// The RPC handlers reach the proof system of an account through its engine only
+	fake := &fakeEngine{}
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, func(cfg *server.Config) {
+		cfg.ServerID = "engine-test"
+		cfg.Engines = []server.Engine{fake}
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	y1, _ := cp_zkp.NewProver(big.NewInt(1234)).GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "alice", Y1: y1.String(), Proof: &api.Proof{C: "1"}, Protocol: "fake"})
+	require.NoError(t, err)
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: &api.Proof{C: "2"}, Protocol: "fake"})
+	require.Error(t, err)
+	require.Equal(t, codes.Code(400), status.Code(err))
+
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: "bob", Y1: y1.String(), Proof: &api.Proof{C: "1"}, Protocol: "other"})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	answer := func(r cp_zkp.Element, s string) error {
+		challengeRes, err := grpcClient.CreateAuthenticationChallenge(ctx, &api.AuthenticationChallengeRequest{
+			User: "alice",
+			R1:   r.String(),
+		})
+		if err != nil {
+			return err
+		}
+		require.Equal(t, "42", challengeRes.C)
+
+		fake.mu.Lock()
+		require.Equal(t, server.AuthContext{ServerID: "engine-test", User: "alice", AuthID: challengeRes.AuthId}, fake.issued[len(fake.issued)-1])
+		fake.mu.Unlock()
+
+		_, err = grpcClient.VerifyAuthentication(ctx, &api.AuthenticationAnswerRequest{AuthId: challengeRes.AuthId, S: s})
+		return err
+	}
+
+	r1, _ := cp_zkp.NewProver(big.NewInt(1)).GenerateYValues(cpzkpParams)
+	r2, _ := cp_zkp.NewProver(big.NewInt(2)).GenerateYValues(cpzkpParams)
+
+	err = answer(r1, "41")
+	require.Error(t, err)
+	require.Equal(t, codes.Code(401), status.Code(err))
+
+	require.NoError(t, answer(r2, "42"))
+
+	// Handlers keep their own checks on top of the engine, e.g. commitment reuse
+	requireInvalidArgument(t, answer(r2, "42"), "r1")
+
+	// The built-in engines stay registered next to the fake one
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	_, err = client.Register(grpcClientV3, config.CPZKP.(*cp_zkp.CPZKP), "carol", "carol-password", kdf)
+	require.NoError(t, err)
+}
+
+// fakeGroupEngine is a `fakeEngine` registered for Chaum-Pedersen that also serves group
+// logins: it always issues the group challenge 42 and accepts a first response `s` of 42
+type fakeGroupEngine struct {
+	*fakeEngine
+
+	groupIssued []server.GroupAuthContext
+}
+
+func (e *fakeGroupEngine) Protocol() string {
+	return cp_zkp.ProtocolChaumPedersen
+}
+
+func (e *fakeGroupEngine) IssueGroupChallenge(auth server.GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment) (*big.Int, []byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.groupIssued = append(e.groupIssued, auth)
+	return big.NewInt(42), []byte(auth.AuthID), nil
+}
+
+func (e *fakeGroupEngine) VerifyGroup(auth server.GroupAuthContext, keys []cp_zkp.Statement, commitments []cp_zkp.Commitment, c *big.Int, nonce []byte, responses []server.GroupResponse) bool {
+	return string(nonce) == auth.AuthID && len(responses) == len(keys) && responses[0].S.Cmp(c) == 0
+}
+
+func TestGRPCServerGroupEngine(t *testing.T) {
+
+	// Group logins reach the proof system through the engine registered for Chaum-Pedersen
+	fake := &fakeGroupEngine{fakeEngine: &fakeEngine{}}
+	grpcClient, config, teardown := SetupGRPCClient(t, func(cfg *server.Config) {
+		cfg.ServerID = "group-engine-test"
+		cfg.Engines = []server.Engine{fake}
+		cfg.Groups = map[string][]string{"team": {"alice", "bob"}}
+	})
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkpParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	for i, user := range []string{"alice", "bob"} {
+		y1, y2 := cp_zkp.NewProver(big.NewInt(int64(1234 + i))).GenerateYValues(cpzkpParams)
+		_, err = grpcClient.Register(ctx, &api.RegisterRequest{User: user, Y1: y1.String(), Y2: y2.String(), Proof: &api.Proof{C: "1"}})
+		require.NoError(t, err)
+	}
+
+	r1, r2 := cp_zkp.NewProver(big.NewInt(1)).GenerateYValues(cpzkpParams)
+	answer := func(s string) (*api.GroupAuthenticationAnswerResponse, error) {
+		challengeRes, err := grpcClient.CreateGroupAuthenticationChallenge(ctx, &api.GroupAuthenticationChallengeRequest{
+			Group:       "team",
+			Users:       []string{"alice", "bob"},
+			Commitments: []*api.Commitment{{R1: r1.String(), R2: r2.String()}, {R1: r2.String(), R2: r1.String()}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "42", challengeRes.C)
+
+		fake.mu.Lock()
+		require.Equal(t, server.GroupAuthContext{ServerID: "group-engine-test", Group: "team", AuthID: challengeRes.AuthId}, fake.groupIssued[len(fake.groupIssued)-1])
+		fake.mu.Unlock()
+
+		return grpcClient.VerifyGroupAuthentication(ctx, &api.GroupAuthenticationAnswerRequest{
+			AuthId:    challengeRes.AuthId,
+			Responses: []*api.Response{{C: "1", S: s}, {C: "1", S: "1"}},
+		})
+	}
+
+	_, err = answer("41")
+	require.Error(t, err)
+	require.Equal(t, grpc_err.ErrInvalidGroupProof{Group: "team"}.Error(), err.Error())
+
+	answerRes, err := answer("42")
+	require.NoError(t, err)
+	require.Equal(t, "team", answerRes.Group)
+	require.NotEmpty(t, answerRes.SessionId)
+}
+
+func TestGRPCServerV3(t *testing.T) {
+
+	// The v3 service shares the accounts of the v2 service and checks the header of every request
+	grpcClient, grpcClientV3, config, teardown := SetupGRPCClients(t, nil)
+	defer teardown()
+
+	ctx := context.Background()
+	cpzkp := config.CPZKP.(*cp_zkp.CPZKP)
+	cpzkpParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+
+	versions, err := grpcClientV3.GetVersions(ctx, &api_v3.VersionsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []api_v3.Version{api_v3.Version_VERSION_2, api_v3.Version_VERSION_3}, versions.Versions)
+	require.Equal(t, cp_zkp.GroupModP, versions.Group)
+	require.Equal(t, []string{cp_zkp.ProtocolChaumPedersen, cp_zkp.ProtocolSchnorr}, versions.Protocols)
+
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	// An account registered over v3 logs in over v2, and the reverse
+	_, err = client.Register(grpcClientV3, cpzkp, "alice", "alice-password", kdf)
+	require.NoError(t, err)
+
+	x, err := cp_zkp.DeriveSecret("alice-password", kdf, cpzkpParams)
+	require.NoError(t, err)
+	require.NoError(t, login(ctx, grpcClient, cpzkpParams, cp_zkp.NewProver(x), "alice"))
+
+	bob := cp_zkp.NewProver(big.NewInt(4242))
+	y1, y2 := bob.GenerateYValues(cpzkpParams)
+	_, err = grpcClient.Register(ctx, &api.RegisterRequest{
+		User:  "bob",
+		Y1:    y1.String(),
+		Y2:    y2.String(),
+		Proof: registrationProof(t, bob, cpzkpParams, "bob"),
+	})
+	require.NoError(t, err)
+
+	header := &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cp_zkp.GroupModP}
+	k, r1, r2, err := bob.CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+
+	challengeRes, err := grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{
+		Header: header,
+		User:   "bob",
+		R1:     r1.Bytes(),
+		R2:     r2.Bytes(),
+	})
+	require.NoError(t, err)
+	require.Len(t, challengeRes.C, cpzkpParams.ScalarSize())
+
+	c, err := cpzkpParams.DecodeScalar(challengeRes.C, "c")
+	require.NoError(t, err)
+
+	verifyRes, err := grpcClientV3.VerifyAuthentication(ctx, &api_v3.AuthenticationAnswerRequest{
+		Header: header,
+		AuthId: challengeRes.AuthId,
+		S:      cpzkpParams.EncodeScalar(bob.CreateProofChallengeResponse(k, c, cpzkpParams)),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, verifyRes.SessionId)
+
+	// Requests must carry a header for v3 over the server's group and the account's protocol
+	k, r1, r2, err = bob.CreateProofCommitment(cpzkpParams)
+	require.NoError(t, err)
+
+	headers := []struct {
+		field  string
+		reason string
+		header *api_v3.Header
+	}{
+		{"header", "missing", nil},
+		{"header.version", "VERSION_2 is not served by this service", &api_v3.Header{Version: api_v3.Version_VERSION_2, Group: cp_zkp.GroupModP}},
+		{"header.group", "the server runs over the modp group", &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cp_zkp.GroupP256}},
+		{"header.protocol", "not accepted by this server", &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cp_zkp.GroupModP, Protocol: "okamoto"}},
+		{"header.protocol", "the account uses the chaum-pedersen protocol", &api_v3.Header{Version: api_v3.Version_VERSION_3, Group: cp_zkp.GroupModP, Protocol: cp_zkp.ProtocolSchnorr}},
+	}
+	for _, tc := range headers {
+		_, err := grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{
+			Header: tc.header,
+			User:   "bob",
+			R1:     r1.Bytes(),
+			R2:     r2.Bytes(),
+		})
+		require.Error(t, err)
+		require.Equal(t, grpc_err.ErrInvalidArgument{Field: tc.field, Reason: tc.reason}.Error(), err.Error())
+	}
+
+	// Only the canonical fixed-length encodings are accepted
+	_, err = grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{
+		Header: header,
+		User:   "bob",
+		R1:     append([]byte{0}, r1.Bytes()...),
+		R2:     r2.Bytes(),
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	challengeRes, err = grpcClientV3.CreateAuthenticationChallenge(ctx, &api_v3.AuthenticationChallengeRequest{
+		Header: header,
+		User:   "bob",
+		R1:     r1.Bytes(),
+		R2:     r2.Bytes(),
+	})
+	require.NoError(t, err)
+
+	c, err = cpzkpParams.DecodeScalar(challengeRes.C, "c")
+	require.NoError(t, err)
+
+	_, err = grpcClientV3.VerifyAuthentication(ctx, &api_v3.AuthenticationAnswerRequest{
+		Header: header,
+		AuthId: challengeRes.AuthId,
+		S:      bob.CreateProofChallengeResponse(k, c, cpzkpParams).Bytes()[1:],
+	})
+	require.Error(t, err)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The client refuses to talk to a server over another group
+	p256, err := cp_zkp.NewCPZKPWithGroup(cp_zkp.GroupP256)
+	require.NoError(t, err)
+
+	_, err = client.LogIn(grpcClientV3, p256, "alice", "alice-password")
+	require.Error(t, err)
+}
+
+func TestGRPCServerGetParameters(t *testing.T) {
+
+	// Clients fetch the server's parameters instead of using their compiled-in ones
+	_, grpcClientV3, config, teardown := SetupGRPCClients(t, func(cfg *server.Config) {
+		cfg.CPZKP = &cp_zkp.CPZKP{Group: cp_zkp.GroupModP, Preset: cp_zkp.PresetMODP2048}
+	})
+	defer teardown()
+
+	serverParams, err := config.CPZKP.InitCPZKPParams()
+	require.NoError(t, err)
+
+	cacheFile := filepath.Join(t.TempDir(), "params.json")
+	cpzkp, err := cp_zkp.NewCPZKP()
+	require.NoError(t, err)
+
+	params, err := client.FetchParams(grpcClientV3, cpzkp, cacheFile)
+	require.NoError(t, err)
+	require.Equal(t, serverParams.Fingerprint(), params.Fingerprint())
+
+	initParams, err := cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+	require.Same(t, params, initParams)
+
+	kdf, err := cp_zkp.NewKDFParams()
+	require.NoError(t, err)
+	kdf.Time, kdf.Memory, kdf.Parallelism = 1, cp_zkp.MinKDFMemory, 1
+
+	_, err = client.Register(grpcClientV3, cpzkp, "alice", "alice-password", kdf)
+	require.NoError(t, err)
+
+	logInRes, err := client.LogIn(grpcClientV3, cpzkp, "alice", "alice-password")
+	require.NoError(t, err)
+	require.NotEmpty(t, logInRes.SessionId)
+
+	// The parameters are pinned on first use, and fetching them again passes
+	_, err = os.Stat(cacheFile)
+	require.NoError(t, err)
+
+	// The pinned copy is loaded and used as long as the server sends the same parameters
+	pinned, err := client.FetchParams(grpcClientV3, cpzkp, cacheFile)
+	require.NoError(t, err)
+	require.Equal(t, params.Fingerprint(), pinned.Fingerprint())
+
+	initParams, err = cpzkp.InitCPZKPParams()
+	require.NoError(t, err)
+	require.Same(t, pinned, initParams)
+
+	// A pinned copy that does not match its fingerprint is refused
+	cache, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+
+	var entries map[string]map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(cache, &entries))
+	preset, err := cp_zkp.NewPresetParams(cp_zkp.PresetFFDHE2048)
+	require.NoError(t, err)
+	entries[cp_zkp.GroupModP]["params"], err = json.Marshal(preset)
+	require.NoError(t, err)
+	tampered, err := json.Marshal(entries)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cacheFile, tampered, 0o600))
+
+	_, err = client.FetchParams(grpcClientV3, cpzkp, cacheFile)
+	require.ErrorContains(t, err, "does not match its fingerprint")
+	require.NoError(t, os.WriteFile(cacheFile, cache, 0o600))
+
+	// The server only has parameters for its own group
+	p256, err := cp_zkp.NewCPZKPWithGroup(cp_zkp.GroupP256)
+	require.NoError(t, err)
+
+	_, err = client.FetchParams(grpcClientV3, p256, cacheFile)
+	require.Error(t, err)
+
+	// A server with other parameters, here the default ffdhe2048 preset, is refused once the
+	// parameters are pinned
+	_, otherClientV3, _, otherTeardown := SetupGRPCClients(t, nil)
+	defer otherTeardown()
+
+	other, err := cp_zkp.NewCPZKP()
+	require.NoError(t, err)
+
+	_, err = client.FetchParams(otherClientV3, other, cacheFile)
+	require.ErrorIs(t, err, client.ErrParamsChanged)
+
+	_, err = client.FetchParams(otherClientV3, other, "")
+	require.NoError(t, err)
+}
@@ -9,6 +9,9 @@ const (
 	CPZKP_PARAM_H string = "9"
 )
 
+// Default identity of the server, mixed into every challenge it issues
+const SERVER_ID string = "zkp_auth-server"
+
 // Only for testing purposes
 var (
 	CPZKP_TEST_X_CORRECT   = "546225242382632051252993"
@@ -2,13 +2,16 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 
 	"github.com/srinathLN7/zkp_auth/cmd"
 	cp_zkp "github.com/srinathLN7/zkp_auth/internal/cpzkp"
 	"github.com/srinathLN7/zkp_auth/internal/server"
+	sys_config "github.com/srinathLN7/zkp_auth/lib/config"
 )
 
 func init() {
@@ -18,17 +21,40 @@ func init() {
 func main() {
 
 	var runServerInBackground = flag.Bool("server", false, "run grpc server in the background")
+	var group = flag.String("group", cp_zkp.GroupModP, "group the protocol runs over (modp, p256, ristretto255)")
+	var paramFile = flag.String("params", "", "parameter file generated with `genparams` (modp group only)")
+	var preset = flag.String("preset", "", "built-in RFC 3526 / RFC 7919 parameter preset, e.g. ffdhe2048 (modp group only)")
+	var protocol = flag.String("protocol", "", "only accept registrations with this protocol (chaum-pedersen, schnorr); empty accepts both")
+	var serverID = flag.String("id", sys_config.SERVER_ID, "server identity mixed into every challenge")
+	var batchWindow = flag.Duration("batch-window", 0, "verify the proofs received within this time window together, e.g. 2ms (0 disables batching)")
+	var batchSize = flag.Int("batch-size", 64, "maximum number of proofs verified in one batch")
+	var groups = make(map[string][]string)
+	flag.Func("member-group", "group for anonymous logins as name=user1,user2,... (repeatable)", func(value string) error {
+		name, members, ok := strings.Cut(value, "=")
+		if !ok || name == "" || members == "" {
+			return fmt.Errorf("expected name=user1,user2,..., got %q", value)
+		}
+		groups[name] = append(groups[name], strings.Split(members, ",")...)
+		return nil
+	})
 	flag.Parse()
 
 	// Check if the --server flag is set
 	if *runServerInBackground {
-		cpzkpParams, err := cp_zkp.NewCPZKP()
+		cpzkpParams, err := cp_zkp.NewCPZKPWithGroup(*group)
 		if err != nil {
 			log.Fatal("error generating system parameters:", err)
 		}
+		cpzkpParams.ParamFile = *paramFile
+		cpzkpParams.Preset = *preset
+		cpzkpParams.Protocol = *protocol
 
 		cfg := &server.Config{
-			CPZKP: cpzkpParams,
+			CPZKP:       cpzkpParams,
+			ServerID:    *serverID,
+			BatchWindow: *batchWindow,
+			BatchSize:   *batchSize,
+			Groups:      groups,
 		}
 
 		// Create and start the gRPC server in the background